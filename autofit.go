@@ -0,0 +1,263 @@
+package rtfdoc
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Glyph widths of printable ASCII characters (32-126) in 1/1000 of em
+var (
+	sansGlyphWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	serifGlyphWidths = [95]int{
+		250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+		921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+		556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+		333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+		500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+	}
+)
+
+const (
+	monoGlyphWidth = 600
+	// boldWidthFactor is approximate widening of bold text in percents
+	boldWidthFactor = 110
+)
+
+// SetAutoFit turns on fitting of column widths to the content. Widths of cells are estimated from
+// text length and font metrics when the Table is written, so the Table never exceeds its maximum width.
+// Columns are defined by cell position in the row.
+func (t *Table) SetAutoFit(autoFit bool) *Table {
+	t.autoFit = autoFit
+	return t
+}
+
+// SetColumnWidthLimits sets minimum and maximum width of the column (in twips) for the auto-fit mode. Zero value means no limit.
+func (t *Table) SetColumnWidthLimits(column int, minWidth int, maxWidth int) *Table {
+	if column < 0 || minWidth < 0 || maxWidth < 0 {
+		return t
+	}
+	for len(t.columnLimits) <= column {
+		t.columnLimits = append(t.columnLimits, columnLimit{})
+	}
+	t.columnLimits[column] = columnLimit{min: minWidth, max: maxWidth}
+	return t
+}
+
+// fitColumns returns column widths, which fit content of the Table into its maximum width
+func (t Table) fitColumns() []int {
+	var minWidths, maxWidths []int
+	// Cells merged horizontally are fitted after the single column ones
+	type spanWidth struct {
+		first, count         int
+		wordWidth, lineWidth int
+	}
+	var spans []spanWidth
+	// Empty column keeps place for the paddings and one character
+	charWidth := Text{fontSize: defaultFontSize / 2}.getWidth("n")
+	for _, tr := range t.data {
+		for i, tc := range tr.cells {
+			padding := tc.paddingLeft + tc.paddingRight + t.paddingLeft + t.paddingRight
+			if i == len(minWidths) {
+				minWidths = append(minWidths, 0)
+				maxWidths = append(maxWidths, 0)
+			}
+			if padding+charWidth > minWidths[i] {
+				minWidths[i] = padding + charWidth
+			}
+			if padding+charWidth > maxWidths[i] {
+				maxWidths[i] = padding + charWidth
+			}
+			if tc.mergeOrigin != nil {
+				continue
+			}
+			wordWidth, lineWidth := tc.getContentWidth()
+			count := 1
+			if tc.horizontalMerged == "gf" {
+				for j := i + 1; j < len(tr.cells) && tr.cells[j].horizontalMerged == "rg"; j++ {
+					count++
+				}
+			}
+			if count > 1 {
+				spans = append(spans, spanWidth{i, count, wordWidth + padding, lineWidth + padding})
+				continue
+			}
+			if wordWidth+padding > minWidths[i] {
+				minWidths[i] = wordWidth + padding
+			}
+			if lineWidth+padding > maxWidths[i] {
+				maxWidths[i] = lineWidth + padding
+			}
+		}
+	}
+	// Content of merged cell, which doesn't fit its columns, widens them evenly
+	widen := func(widths []int, first, count, width int) {
+		for i := first; i < first+count; i++ {
+			width -= widths[i]
+		}
+		for i := first; width > 0 && i < first+count; i++ {
+			widths[i] += width / count
+			if i == first {
+				widths[i] += width % count
+			}
+		}
+	}
+	for _, s := range spans {
+		widen(minWidths, s.first, s.count, s.wordWidth)
+		widen(maxWidths, s.first, s.count, s.lineWidth)
+	}
+
+	sumMin, sumMax := 0, 0
+	for i := range minWidths {
+		if i < len(t.columnLimits) {
+			l := t.columnLimits[i]
+			if minWidths[i] < l.min {
+				minWidths[i] = l.min
+			}
+			if l.max > 0 && maxWidths[i] > l.max {
+				maxWidths[i] = l.max
+			}
+			if l.max > 0 && minWidths[i] > l.max {
+				minWidths[i] = l.max
+			}
+		}
+		if maxWidths[i] < minWidths[i] {
+			maxWidths[i] = minWidths[i]
+		}
+		sumMin += minWidths[i]
+		sumMax += maxWidths[i]
+	}
+
+	available := t.maxWidth
+	if t.width > 0 && t.width < available {
+		available = t.width
+	}
+	if available < 0 {
+		// Table doesn't fit its container (e.g. nested in narrow cell)
+		available = 0
+	}
+	widths := make([]int, len(minWidths))
+	for i := range widths {
+		switch {
+		case sumMax <= available:
+			widths[i] = maxWidths[i]
+		case sumMin <= available:
+			// Columns take minimum width and share the rest proportionally to their need
+			widths[i] = minWidths[i] + (maxWidths[i]-minWidths[i])*(available-sumMin)/(sumMax-sumMin)
+		case sumMin > 0:
+			widths[i] = minWidths[i] * available / sumMin
+		}
+	}
+	return widths
+}
+
+// getContentWidth returns estimated width of the longest word and of the longest line of cell text in twips
+func (dc TableCell) getContentWidth() (wordWidth int, lineWidth int) {
+	for _, c := range dc.content {
+		p, ok := c.(*Paragraph)
+		if !ok {
+			if t, ok := c.(*Table); ok {
+				// Nested table keeps its width
+				tableWidth := t.getWidth() + t.marginLeft + t.marginRight
+				if tableWidth > wordWidth {
+					wordWidth = tableWidth
+				}
+				if tableWidth > lineWidth {
+					lineWidth = tableWidth
+				}
+			}
+			continue
+		}
+		line := 0
+		for _, item := range p.content {
+			var text *Text
+			switch v := item.(type) {
+			case *Text:
+				text = v
+			case *Field:
+				text = &v.Text
+			case *controlWord:
+				if v.word == "line" {
+					line = 0
+				}
+				continue
+			default:
+				continue
+			}
+			for i, l := range strings.Split(text.content, "\n") {
+				if i > 0 {
+					line = 0
+				}
+				for _, word := range strings.Fields(l) {
+					if w := text.getWidth(word); w > wordWidth {
+						wordWidth = w
+					}
+				}
+				line += text.getWidth(l)
+				if line > lineWidth {
+					lineWidth = line
+				}
+			}
+		}
+	}
+	return wordWidth, lineWidth
+}
+
+// getWidth returns width of the widest Table row
+func (t Table) getWidth() int {
+	if t.autoFit {
+		width := 0
+		for _, w := range t.fitColumns() {
+			width += w
+		}
+		return width
+	}
+	width := 0
+	for _, tr := range t.data {
+		if w := tr.getWidth(); w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// getWidth returns estimated width of s written with the Text font in twips
+func (text Text) getWidth(s string) int {
+	glyphs := &sansGlyphWidths
+	mono := false
+	if text.fontColor != nil && text.fontCode < len(*text.fontColor) {
+		f := (*text.fontColor)[text.fontCode]
+		switch {
+		case f.prq == 1 || f.family == "modern":
+			mono = true
+		case f.family == "roman":
+			glyphs = &serifGlyphWidths
+		}
+	}
+	width := 0
+	for _, r := range s {
+		switch {
+		case mono:
+			width += monoGlyphWidth
+		case r >= 32 && r <= 126:
+			width += glyphs[r-32]
+		case unicode.IsUpper(r):
+			width += glyphs['H'-32]
+		default:
+			width += glyphs['n'-32]
+		}
+	}
+	// Font size is in points, point is 20 twips
+	width = width * text.fontSize * 20 / 1000
+	if text.isBold {
+		width = width * boldWidthFactor / 100
+	}
+	return width
+}
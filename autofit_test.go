@@ -0,0 +1,133 @@
+package rtfdoc
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFitColumns(t *testing.T) {
+	charWidth := Text{fontSize: 12}.getWidth("n")
+	word := func(s string) int { return Text{fontSize: 12}.getWidth(s) }
+	tests := []struct {
+		name  string
+		build func(t *Table)
+		want  []int
+	}{
+		{
+			name: "column takes width of its content",
+			build: func(t *Table) {
+				tr := t.AddTableRow()
+				tr.AddDataCell(1000).AddParagraph().AddText("one two", 12, FontArial, ColorBlack)
+				tr.AddDataCell(1000).AddParagraph().AddText("three", 12, FontArial, ColorBlack)
+			},
+			want: []int{word("one two"), word("three")},
+		},
+		{
+			name: "empty column keeps width of one character",
+			build: func(t *Table) {
+				tr := t.AddTableRow()
+				tr.AddDataCell(1000).AddParagraph().AddText("text", 12, FontArial, ColorBlack)
+				tr.AddDataCell(1000)
+			},
+			want: []int{word("text"), charWidth},
+		},
+		{
+			name: "empty column keeps place for paddings",
+			build: func(t *Table) {
+				tr := t.AddTableRow()
+				tr.AddDataCell(1000).SetPadding(100)
+			},
+			want: []int{200 + charWidth},
+		},
+		{
+			name: "merged cell content is spread over its columns",
+			build: func(t *Table) {
+				tr := t.AddTableRow()
+				tr.AddDataCellSpan(1000, 2, 1).AddParagraph().AddText("mmmmmmmmmm", 12, FontArial, ColorBlack)
+				tr = t.AddTableRow()
+				tr.AddDataCell(1000).AddParagraph().AddText("m", 12, FontArial, ColorBlack)
+				tr.AddDataCell(1000).AddParagraph().AddText("m", 12, FontArial, ColorBlack)
+			},
+			want: []int{(word("mmmmmmmmmm") + 1) / 2, word("mmmmmmmmmm") / 2},
+		},
+		{
+			name: "column limits",
+			build: func(t *Table) {
+				t.SetColumnWidthLimits(0, 0, 500).SetColumnWidthLimits(1, 1500, 0)
+				tr := t.AddTableRow()
+				tr.AddDataCell(1000).AddParagraph().AddText("a long line of text", 12, FontArial, ColorBlack)
+				tr.AddDataCell(1000).AddParagraph().AddText("a", 12, FontArial, ColorBlack)
+			},
+			want: []int{500, 1500},
+		},
+		{
+			name: "narrow table shares width between columns",
+			build: func(t *Table) {
+				t.SetWidth(word("aaaa") + word("bbbb"))
+				tr := t.AddTableRow()
+				tr.AddDataCell(1000).AddParagraph().AddText(strings.Repeat("aaaa ", 10), 12, FontArial, ColorBlack)
+				tr.AddDataCell(1000).AddParagraph().AddText("bbbb", 12, FontArial, ColorBlack)
+			},
+			want: []int{word("aaaa"), word("bbbb")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			table := doc.AddTable().SetAutoFit(true)
+			tt.build(table)
+			got := table.fitColumns()
+			if len(got) != len(tt.want) {
+				t.Fatalf("got widths %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got widths %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestAutoFitCompose(t *testing.T) {
+	word := func(s string) int { return Text{fontSize: 12}.getWidth(s) }
+	tests := []struct {
+		name  string
+		build func(t *Table)
+		want  []int
+	}{
+		{
+			name:  "cell widths are replaced",
+			build: func(t *Table) { t.SetAutoFit(true) },
+			want:  []int{word("one"), word("one") + word("three")},
+		},
+		{
+			name:  "table style paddings are counted",
+			build: func(t *Table) { t.SetAutoFit(true).ApplyStyle(TableStyleGrid) },
+			want:  []int{word("one") + 120, word("one") + word("three") + 240},
+		},
+		{
+			name:  "auto-fit is off",
+			build: func(t *Table) { t.SetAutoFit(true).SetAutoFit(false) },
+			want:  []int{3000, 6000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			table := doc.AddTable()
+			tt.build(table)
+			tr := table.AddTableRow()
+			tr.AddDataCell(3000).AddParagraph().AddText("one", 12, FontArial, ColorBlack)
+			tr.AddDataCell(3000).AddParagraph().AddText("three", 12, FontArial, ColorBlack)
+			body := exportBody(doc)
+			for _, w := range tt.want {
+				if s := fmt.Sprintf("\\cellx%d\n", w); !strings.Contains(body, s) {
+					t.Errorf("%q not found in\n%s", s, body)
+				}
+			}
+		})
+	}
+}
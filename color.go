@@ -5,6 +5,26 @@ import (
 	"image/color"
 )
 
+// defaultColorTable holds colors of the new Document
+var defaultColorTable = ColorTable{
+	{color.RGBA{R: 0, G: 0, B: 0, A: 255}, ColorBlack},
+	{color.RGBA{R: 0, G: 0, B: 255, A: 255}, ColorBlue},
+	{color.RGBA{R: 0, G: 255, B: 255, A: 255}, ColorAqua},
+	{color.RGBA{R: 0, G: 255, B: 0, A: 255}, ColorLime},
+	{color.RGBA{R: 0, G: 128, B: 0, A: 255}, ColorGreen},
+	{color.RGBA{R: 255, G: 0, B: 255, A: 255}, ColorMagenta},
+	{color.RGBA{R: 255, G: 0, B: 0, A: 255}, ColorRed},
+	{color.RGBA{R: 255, G: 255, B: 0, A: 255}, ColorYellow},
+	{color.RGBA{R: 255, G: 255, B: 255, A: 255}, ColorWhite},
+	{color.RGBA{R: 0, G: 0, B: 128, A: 255}, ColorNavy},
+	{color.RGBA{R: 0, G: 128, B: 128, A: 255}, ColorTeal},
+	{color.RGBA{R: 128, G: 0, B: 128, A: 255}, ColorPurple},
+	{color.RGBA{R: 128, G: 0, B: 0, A: 255}, ColorMaroon},
+	{color.RGBA{R: 128, G: 128, B: 0, A: 255}, ColorOlive},
+	{color.RGBA{R: 128, G: 128, B: 128, A: 255}, ColorGray},
+	{color.RGBA{R: 192, G: 192, B: 192, A: 255}, ColorSilver},
+}
+
 func (col colorItem) encode() string {
 	r, g, b, _ := col.rgbColor.RGBA()
 	return fmt.Sprintf("\\red%d\\green%d\\blue%d;", r/256, g/256, b/256)
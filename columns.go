@@ -0,0 +1,135 @@
+package rtfdoc
+
+import "fmt"
+
+// defaultColumnSpacing is space between columns (in twips) used by rtf readers by default
+const defaultColumnSpacing = 720
+
+// SetColumns sets number of newspaper-style columns of the Document. Content added after the call
+// gets width of the column as its maximum width.
+func (doc *Document) SetColumns(count int) *Document {
+	doc.setColumns(count)
+	doc.updateMaxWidth()
+	return doc
+}
+
+// SetColumnSpacing sets space between columns of the Document (in twips)
+func (doc *Document) SetColumnSpacing(spacing int) *Document {
+	doc.setColumnSpacing(spacing)
+	doc.updateMaxWidth()
+	return doc
+}
+
+// SetColumnWidths sets columns of the Document with individual widths (in twips).
+// Columns are separated by column spacing, maximum width of the content is the width of the narrowest column.
+func (doc *Document) SetColumnWidths(widths ...int) *Document {
+	doc.setColumnWidths(widths)
+	doc.updateMaxWidth()
+	return doc
+}
+
+// SetLineBetweenColumns turns on vertical line between columns of the Document
+func (doc *Document) SetLineBetweenColumns(lineBetween bool) *Document {
+	doc.lineBetween = lineBetween
+	return doc
+}
+
+// SetColumns sets number of newspaper-style columns in the Section. Content added after the call
+// gets width of the column as its maximum width.
+func (s *Section) SetColumns(count int) *Section {
+	s.setColumns(count)
+	s.updateMaxWidth()
+	return s
+}
+
+// SetColumnSpacing sets space between columns of the Section (in twips)
+func (s *Section) SetColumnSpacing(spacing int) *Section {
+	s.setColumnSpacing(spacing)
+	s.updateMaxWidth()
+	return s
+}
+
+// SetColumnWidths sets columns of the Section with individual widths (in twips).
+// Columns are separated by column spacing, maximum width of the content is the width of the narrowest column.
+func (s *Section) SetColumnWidths(widths ...int) *Section {
+	s.setColumnWidths(widths)
+	s.updateMaxWidth()
+	return s
+}
+
+// SetLineBetweenColumns turns on vertical line between columns of the Section
+func (s *Section) SetLineBetweenColumns(lineBetween bool) *Section {
+	s.lineBetween = lineBetween
+	return s
+}
+
+func (cl *columnLayout) setColumns(count int) {
+	if count <= 0 {
+		return
+	}
+	if count != len(cl.columnWidths) {
+		// Equal columns replace individual widths
+		cl.columnWidths = nil
+	}
+	cl.columns = count
+}
+
+func (cl *columnLayout) setColumnSpacing(spacing int) {
+	if spacing >= 0 {
+		cl.columnSpacing = spacing
+	}
+}
+
+func (cl *columnLayout) setColumnWidths(widths []int) {
+	if len(widths) == 0 {
+		return
+	}
+	for _, w := range widths {
+		if w <= 0 {
+			return
+		}
+	}
+	cl.columns = len(widths)
+	cl.columnWidths = append([]int(nil), widths...)
+}
+
+// getColumnWidth returns width of the content in the column for page content width
+func (cl columnLayout) getColumnWidth(pageWidth int) int {
+	if cl.columns < 2 {
+		return pageWidth
+	}
+	if len(cl.columnWidths) > 0 {
+		width := cl.columnWidths[0]
+		for _, w := range cl.columnWidths[1:] {
+			if w < width {
+				width = w
+			}
+		}
+		if width > pageWidth {
+			width = pageWidth
+		}
+		return width
+	}
+	width := (pageWidth - cl.columnSpacing*(cl.columns-1)) / cl.columns
+	if width <= 0 {
+		return pageWidth
+	}
+	return width
+}
+
+func (cl columnLayout) getColumnProperties() string {
+	if cl.columns < 2 {
+		return ""
+	}
+	res := fmt.Sprintf("\n\\cols%d\\colsx%d", cl.columns, cl.columnSpacing)
+	for i, w := range cl.columnWidths {
+		res += fmt.Sprintf("\\colno%d\\colw%d", i+1, w)
+		if i < len(cl.columnWidths)-1 {
+			res += fmt.Sprintf("\\colsr%d", cl.columnSpacing)
+		}
+	}
+	if cl.lineBetween {
+		res += "\\linebetcol"
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	// Content width of A4 page with default margins
+	const pageWidth = 11952 - 2*720
+	tests := []struct {
+		name  string
+		build func(doc *Document)
+		want  string
+		width int
+	}{
+		{"single column", func(doc *Document) { doc.SetColumns(1) }, "", pageWidth},
+		{"equal columns", func(doc *Document) { doc.SetColumns(2) }, "\n\\cols2\\colsx720", (pageWidth - 720) / 2},
+		{"column spacing and line", func(doc *Document) {
+			doc.SetColumns(3).SetColumnSpacing(360).SetColumnSpacing(-1).SetLineBetweenColumns(true)
+		}, "\n\\cols3\\colsx360\\linebetcol", (pageWidth - 720) / 3},
+		{"column widths", func(doc *Document) {
+			doc.SetColumnSpacing(500).SetColumnWidths(3000, 2000)
+		}, "\n\\cols2\\colsx500\\colno1\\colw3000\\colsr500\\colno2\\colw2000", 2000},
+		{"invalid column widths are ignored", func(doc *Document) {
+			doc.SetColumnWidths(3000, 0).SetColumnWidths().SetColumns(0)
+		}, "", pageWidth},
+		{"columns count replaces widths", func(doc *Document) {
+			doc.SetColumnWidths(3000, 2000).SetColumns(3)
+		}, "\n\\cols3\\colsx720", (pageWidth - 720*2) / 3},
+		{"same columns count keeps widths", func(doc *Document) {
+			doc.SetColumnWidths(3000, 2000).SetColumns(2)
+		}, "\n\\cols2\\colsx720\\colno1\\colw3000\\colsr720\\colno2\\colw2000", 2000},
+		{"too many columns", func(doc *Document) { doc.SetColumns(100) }, "\n\\cols100\\colsx720", pageWidth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			if got := doc.getColumnProperties(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if got := doc.GetMaxContentWidth(); got != tt.width {
+				t.Errorf("got content width %d, want %d", got, tt.width)
+			}
+			if got := doc.AddParagraph().maxWidth; got != tt.width {
+				t.Errorf("got paragraph width %d, want %d", got, tt.width)
+			}
+		})
+	}
+}
+
+func TestSectionColumns(t *testing.T) {
+	doc := NewDocument().SetColumns(2)
+	inherited := doc.AddSection()
+	own := doc.AddSection().SetColumns(1)
+	if inherited.GetMaxContentWidth() != doc.GetMaxContentWidth() {
+		t.Errorf("got section width %d, want %d", inherited.GetMaxContentWidth(), doc.GetMaxContentWidth())
+	}
+	if want := 11952 - 2*720; own.GetMaxContentWidth() != want {
+		t.Errorf("got section width %d, want %d", own.GetMaxContentWidth(), want)
+	}
+	body := exportBody(doc)
+	if want := "\\margbsxn720\n\\cols2\\colsx720"; strings.Count(body, want) != 1 {
+		t.Errorf("%q not found once in\n%s", want, body)
+	}
+
+	// Columns are kept by the reader
+	parsed, err := Parse(strings.NewReader(string(doc.Export())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again := exportBody(parsed); again != body {
+		t.Errorf("export of parsed document differs:\n%s\n---\n%s", body, again)
+	}
+}
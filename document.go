@@ -5,9 +5,10 @@
 package rtfdoc
 
 import (
+	"bytes"
 	"fmt"
 	"image/color"
-	"strings"
+	"io"
 )
 
 // default compose
@@ -15,9 +16,10 @@ import (
 // NewDocument returns new rtf Document instance
 func NewDocument() *Document {
 	doc := Document{
-		orientation: OrientationPortrait,
-		header:      getDefaultHeader(),
-		content:     nil,
+		orientation:   OrientationPortrait,
+		header:        getDefaultHeader(),
+		content:       nil,
+		outputProfile: OutputProfileFull,
 	}
 	doc.marginLeft = 720
 	doc.marginRight = 720
@@ -27,38 +29,28 @@ func NewDocument() *Document {
 	doc.SetFormat(FormatA4)
 	doc.SetOrientation(OrientationPortrait)
 
-	doc.maxWidth = doc.pagesize.width - doc.marginRight - doc.marginLeft
+	doc.columnSpacing = defaultColumnSpacing
+	doc.updateMaxWidth()
 
-	// Default fonts
+	// Default fonts and colortable
 	ft := doc.NewFontTable()
-	ft.AddFont("roman", 0, 2, "Times New Roman", FontTimesNewRoman)
-	ft.AddFont("roman", 2, 2, "Symbol", FontSymbol)
-	ft.AddFont("swiss", 0, 2, "Arial", FontArial)
-	ft.AddFont("swiss", 0, 2, "Comic Sans MS", FontComicSansMS)
-	ft.AddFont("modern", 128, 1, "Curier New", FontCourierNew)
-
-	// Default colortable
+	*ft = append(*ft, defaultFontTable...)
 	ct := doc.NewColorTable()
-	ct.AddColor(color.RGBA{R: 0, G: 0, B: 0, A: 255}, ColorBlack)
-	ct.AddColor(color.RGBA{R: 0, G: 0, B: 255, A: 255}, ColorBlue)
-	ct.AddColor(color.RGBA{R: 0, G: 255, B: 255, A: 255}, ColorAqua)
-	ct.AddColor(color.RGBA{R: 0, G: 255, B: 0, A: 255}, ColorLime)
-	ct.AddColor(color.RGBA{R: 0, G: 128, B: 0, A: 255}, ColorGreen)
-	ct.AddColor(color.RGBA{R: 255, G: 0, B: 255, A: 255}, ColorMagenta)
-	ct.AddColor(color.RGBA{R: 255, G: 0, B: 0, A: 255}, ColorRed)
-	ct.AddColor(color.RGBA{R: 255, G: 255, B: 0, A: 255}, ColorYellow)
-	ct.AddColor(color.RGBA{R: 255, G: 255, B: 255, A: 255}, ColorWhite)
-	ct.AddColor(color.RGBA{R: 0, G: 0, B: 128, A: 255}, ColorNavy)
-	ct.AddColor(color.RGBA{R: 0, G: 128, B: 128, A: 255}, ColorTeal)
-	ct.AddColor(color.RGBA{R: 128, G: 0, B: 128, A: 255}, ColorPurple)
-	ct.AddColor(color.RGBA{R: 128, G: 0, B: 0, A: 255}, ColorMaroon)
-	ct.AddColor(color.RGBA{R: 128, G: 128, B: 0, A: 255}, ColorOlive)
-	ct.AddColor(color.RGBA{R: 128, G: 128, B: 128, A: 255}, ColorGray)
-	ct.AddColor(color.RGBA{R: 192, G: 192, B: 192, A: 255}, ColorSilver)
+	*ct = append(*ct, defaultColorTable...)
+
+	// Default stylesheet
+	doc.NewStyleSheet()
+	doc.NewListTable()
 
 	return &doc
 }
 
+// AddPageBreak adds page break between document paragraphs
+func (doc *Document) AddPageBreak() *Document {
+	doc.content = append(doc.content, &controlWord{word: "page"})
+	return doc
+}
+
 func (doc *Document) getMargins() string {
 	return fmt.Sprintf("\n\\margl%d\\margr%d\\margt%d\\margb%d",
 		doc.marginLeft,
@@ -67,24 +59,36 @@ func (doc *Document) getMargins() string {
 		doc.marginBottom)
 }
 
-func (doc *Document) compose() string {
-	var result strings.Builder
-	result.WriteString("{")
-	result.WriteString(doc.header.compose())
+func (doc *Document) compose(w *rtfWriter) {
+	w.WriteString("{")
+	doc.header.compose(w)
 	if doc.orientation == OrientationLandscape {
-		result.WriteString(fmt.Sprintf("\n\\landscape"))
+		w.WriteString("\n\\landscape")
 	}
 	if doc.pagesize != (size{}) {
-		result.WriteString(fmt.Sprintf("\n\\paperw%d\\paperh%d", doc.pagesize.width, doc.pagesize.height))
+		w.printf("\n\\paperw%d\\paperh%d", doc.pagesize.width, doc.pagesize.height)
 	}
 
-	result.WriteString(doc.getMargins())
+	w.WriteString(doc.getMargins())
+	w.WriteString(doc.getColumnProperties())
+	facingPages := hasFacingPages(doc.headers)
+	for _, s := range doc.sections {
+		facingPages = facingPages || hasFacingPages(s.headers)
+	}
+	if facingPages {
+		w.WriteString("\n\\facingp")
+	}
+	w.WriteString(doc.getNotesProperties())
+	composeHeaders(w, doc.headers)
 
 	for _, c := range doc.content {
-		result.WriteString(fmt.Sprintf("\n%s", c.compose()))
+		w.WriteString("\n")
+		c.compose(w)
+	}
+	for _, s := range doc.sections {
+		s.compose(w)
 	}
-	result.WriteString("\n}")
-	return result.String()
+	w.WriteString("\n}")
 }
 
 // SetFormat sets page format (A2, A3, A4)
@@ -118,6 +122,17 @@ func (doc *Document) SetOrientation(orientation string) *Document {
 	return doc
 }
 
+// SetOutputProfile sets Document output profile: full character formatting (default)
+// or plain text with font sizes only
+func (doc *Document) SetOutputProfile(profile string) *Document {
+	for _, i := range []string{OutputProfileFull, OutputProfilePlainText} {
+		if profile == i {
+			doc.outputProfile = i
+		}
+	}
+	return doc
+}
+
 // // GetDocumentWidth - returns Document width
 // func (doc *Document) GetDocumentWidth() int {
 // 	return doc.pagesize.width
@@ -126,24 +141,28 @@ func (doc *Document) SetOrientation(orientation string) *Document {
 // SetMarginLeft sets left margin for Document work area
 func (doc *Document) SetMarginLeft(value int) *Document {
 	doc.marginLeft = value
+	doc.updateMaxWidth()
 	return doc
 }
 
 // SetMarginRight sets right margin for Document work area
 func (doc *Document) SetMarginRight(value int) *Document {
 	doc.marginRight = value
+	doc.updateMaxWidth()
 	return doc
 }
 
 // SetMarginTop sets top margin for Document work area
 func (doc *Document) SetMarginTop(value int) *Document {
 	doc.marginTop = value
+	doc.updateMaxWidth()
 	return doc
 }
 
 // SetMarginBottom sets bottom margin for Document work area
 func (doc *Document) SetMarginBottom(value int) *Document {
 	doc.marginBottom = value
+	doc.updateMaxWidth()
 	return doc
 }
 
@@ -169,8 +188,13 @@ func (doc *Document) GetMaxContentWidth() int {
 	return doc.getMaxWidth()
 }
 
+// getPageWidth returns width of the page between margins
+func (doc *Document) getPageWidth() int {
+	return doc.pagesize.width - doc.marginRight - doc.marginLeft
+}
+
 func (doc *Document) updateMaxWidth() {
-	doc.maxWidth = doc.pagesize.width - doc.marginRight - doc.marginLeft
+	doc.maxWidth = doc.getColumnWidth(doc.getPageWidth())
 }
 
 // GetTableCellWidthByRatio - returns slice of cell widths from cells ratios
@@ -190,9 +214,17 @@ func (doc *Document) GetTableCellWidthByRatio(tableWidth int, ratio ...float64)
 	return cellWidth
 }
 
+// WriteTo streams Document to w without building it in memory.
+// It implements io.WriterTo interface.
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	return writeComposedWithProfile(w, doc.outputProfile, doc.compose)
+}
+
 // Export exports Document
 func (doc *Document) Export() []byte {
-	return []byte(doc.compose())
+	var buf bytes.Buffer
+	_, _ = doc.WriteTo(&buf)
+	return buf.Bytes()
 }
 
 // AddFont function adds font to Document header
@@ -1,14 +1,135 @@
 package rtfdoc_test
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
 	rtfdoc "github.com/therox/rtf-doc"
 )
 
+// printBody prints document content following the document header
+func printBody(bs []byte) {
+	s := string(bs)
+	fmt.Println(strings.TrimSpace(s[strings.Index(s, "\\fet2")+len("\\fet2"):]))
+}
+
 func ExampleDocument() {
 	doc := rtfdoc.NewDocument()
 	doc.AddParagraph().SetAlign(rtfdoc.AlignCenter).AddText("Hello, world!", 14, rtfdoc.FontTimesNewRoman, rtfdoc.ColorAqua)
 	bs := doc.Export()
 	fmt.Println(string(bs))
 }
+
+func ExampleDocument_WriteTo() {
+	doc := rtfdoc.NewDocument()
+	doc.AddParagraph().AddText("Streamed straight to the output", 14, rtfdoc.FontArial, rtfdoc.ColorBlack)
+	var buf bytes.Buffer
+	n, err := doc.WriteTo(&buf)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(n == int64(buf.Len()))
+	printBody(buf.Bytes())
+	// Output:
+	// true
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f2\fs28\cf1 Streamed straight to the output}}\par
+	// }
+}
+
+func ExampleParse() {
+	src := `{\rtf1\ansi\deff0{\fonttbl{\f0\froman Times New Roman;}}\pard\qc\fs28 Template {\b title}\par}`
+	doc, err := rtfdoc.Parse(strings.NewReader(src))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	doc.AddParagraph().AddText("Appended paragraph", 14, rtfdoc.FontTimesNewRoman, rtfdoc.ColorBlack)
+	printBody(doc.Export())
+	// Output:
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f0\fs28\cf0 Template }
+	// {\f0\fs28\cf0\b title}}\par
+	//
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f0\fs28\cf1 Appended paragraph}}\par
+	// }
+}
+
+func ExampleDocument_AddHeader() {
+	doc := rtfdoc.NewDocument()
+	doc.AddHeader(rtfdoc.HeaderFooterAll).AddParagraph().AddText("Letterhead", 10, rtfdoc.FontArial, rtfdoc.ColorGray)
+	doc.AddFooter(rtfdoc.HeaderFooterAll).AddParagraph().AddText("Confidential", 8, rtfdoc.FontArial, rtfdoc.ColorRed)
+	doc.AddParagraph().AddText("Body text", 12, rtfdoc.FontTimesNewRoman, rtfdoc.ColorBlack)
+	printBody(doc.Export())
+	// Output:
+	// {\header
+	//
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f2\fs20\cf15 Letterhead}}\par
+	// }
+	// {\footer
+	//
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f2\fs16\cf7 Confidential}}\par
+	// }
+	//
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f0\fs24\cf1 Body text}}\par
+	// }
+}
+
+func ExampleDocument_AddSection() {
+	doc := rtfdoc.NewDocument()
+	doc.AddParagraph().AddText("Portrait cover", 24, rtfdoc.FontArial, rtfdoc.ColorBlack)
+
+	s := doc.AddSection().SetOrientation(rtfdoc.OrientationLandscape)
+	t := s.AddTable().SetWidth(s.GetMaxContentWidth())
+	tr := t.AddTableRow()
+	for _, w := range t.GetTableCellWidthByRatio(1, 2, 1) {
+		tr.AddDataCell(w).AddParagraph().AddText("Wide table cell", 12, rtfdoc.FontArial, rtfdoc.ColorBlack)
+	}
+	printBody(doc.Export())
+	// Output:
+	// \pard \qc \fi0 \li0 \ri0 {
+	// {\f2\fs48\cf1 Portrait cover}}\par
+	// \sect\sectd\lndscpsxn\pgwsxn16848\pghsxn11952
+	// \marglsxn720\margrsxn720\margtsxn720\margbsxn720
+	//
+	// {\trowd \trqc
+	// \trpaddl0 \trpaddr0 \trpaddt0 \trpaddb0
+	// \trwWidth15408\trftsWidth3
+	//
+	// \trbrdrl\brdrw15\brdrs\brdrcf1
+	// \trbrdrr\brdrw15\brdrs\brdrcf1
+	// \trbrdrt\brdrw15\brdrs\brdrcf1
+	// \trbrdrb\brdrw15\brdrs\brdrcf1
+	// \clbrdrl\brdrw15\brdrs\brdrcf1
+	// \clbrdrr\brdrw15\brdrs\brdrcf1
+	// \clbrdrt\brdrw15\brdrs\brdrcf1
+	// \clbrdrb\brdrw15\brdrs\brdrcf1
+	// \clpadl0\clpadr0\clpadt0\clpadb0\clvertal\cellx3852
+	// \clbrdrl\brdrw15\brdrs\brdrcf1
+	// \clbrdrr\brdrw15\brdrs\brdrcf1
+	// \clbrdrt\brdrw15\brdrs\brdrcf1
+	// \clbrdrb\brdrw15\brdrs\brdrcf1
+	// \clpadl0\clpadr0\clpadt0\clpadb0\clvertal\cellx11556
+	// \clbrdrl\brdrw15\brdrs\brdrcf1
+	// \clbrdrr\brdrw15\brdrs\brdrcf1
+	// \clbrdrt\brdrw15\brdrs\brdrcf1
+	// \clbrdrb\brdrw15\brdrs\brdrcf1
+	// \clpadl0\clpadr0\clpadt0\clpadb0\clvertal\cellx15408
+	//
+	// \pard \ql \fi0 \li0 \ri0 {\intbl
+	// {\f2\fs24\cf1 Wide table cell}}
+	// \cell
+	// \pard \ql \fi0 \li0 \ri0 {\intbl
+	// {\f2\fs24\cf1 Wide table cell}}
+	// \cell
+	// \pard \ql \fi0 \li0 \ri0 {\intbl
+	// {\f2\fs24\cf1 Wide table cell}}
+	// \cell\row}
+	// }
+}
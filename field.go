@@ -0,0 +1,114 @@
+package rtfdoc
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// timeNow returns time of the date and time field placeholders, tests replace it to get stable output
+var timeNow = time.Now
+
+// AddField adds dynamic field of fieldType (FieldPage, FieldNumPages, FieldDate, etc.) into Paragraph.
+// Returned field may be formatted as any other text.
+func (p *Paragraph) AddField(fieldType string, opts FieldOptions) *Field {
+	placeholder := opts.Placeholder
+	if placeholder == "" {
+		switch fieldType {
+		case FieldDate:
+			placeholder = timeNow().Format("02.01.2006")
+		case FieldTime:
+			placeholder = timeNow().Format("15:04")
+		default:
+			placeholder = "1"
+		}
+	}
+	f := Field{
+		Text: Text{
+			fontSize: defaultFontSize / 2,
+			content:  placeholder,
+			generalSettings: generalSettings{
+				colorTable: p.colorTable,
+				fontColor:  p.fontColor,
+				styleSheet: p.styleSheet,
+				listTable:  p.listTable,
+			},
+		},
+		fieldType: fieldType,
+		format:    opts.Format,
+	}
+	p.content = append(p.content, &f)
+	return &f
+}
+
+// AddHyperlink adds link to url with text into Paragraph, url is shown if text is empty
+func (p *Paragraph) AddHyperlink(text string, url string, fontSize int, fontCode string, colorCode string) *Field {
+	if text == "" {
+		text = url
+	}
+	f := p.AddField(FieldHyperlink, FieldOptions{Placeholder: text})
+	f.argument = url
+	f.SetFontSize(fontSize).SetFont(fontCode).SetColor(colorCode).SetUnderlining()
+	return f
+}
+
+// AddInternalLink adds link to the bookmark of the document with text into Paragraph
+func (p *Paragraph) AddInternalLink(text string, bookmarkName string, fontSize int, fontCode string, colorCode string) *Field {
+	f := p.AddHyperlink(text, bookmarkName, fontSize, fontCode, colorCode)
+	f.switches = "\\l"
+	return f
+}
+
+// AddBookmark adds named bookmark at the current position of Paragraph, so it may be referenced by internal links
+func (p *Paragraph) AddBookmark(name string) *Paragraph {
+	p.content = append(p.content, &bookmark{name: name})
+	return p
+}
+
+func (b bookmark) compose(w *rtfWriter) {
+	name := escapeText(b.name)
+	w.printf("\n{\\*\\bkmkstart %s}{\\*\\bkmkend %s}", name, name)
+}
+
+// instruction returns field instruction with format switches
+func (f Field) instruction() string {
+	res := f.fieldType
+	if f.switches != "" {
+		res += " " + f.switches
+	}
+	if f.argument != "" {
+		res += fmt.Sprintf(" \"%s\"", quoteFieldArgument(f.argument))
+	}
+	if f.format != "" {
+		switch f.fieldType {
+		case FieldDate, FieldTime:
+			res += fmt.Sprintf(" \\@ \"%s\"", quoteFieldArgument(f.format))
+		default:
+			res += fmt.Sprintf(" \\* %s", f.format)
+		}
+	}
+	if f.fieldType == FieldHyperlink {
+		return res
+	}
+	// Keep field result formatting on update
+	return res + " \\* MERGEFORMAT"
+}
+
+// quoteFieldArgument escapes backslashes and quotes of the quoted field instruction argument
+func quoteFieldArgument(arg string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(arg)
+}
+
+func (f Field) compose(w *rtfWriter) {
+	w.WriteString("\n{\\field{\\*\\fldinst")
+	f.Text.composeFormatted(w, escapeText(f.instruction()))
+	w.WriteString("}{\\fldrslt")
+	f.Text.compose(w)
+	w.WriteString("}}")
+}
+
+// WriteTo streams Field to w. It implements io.WriterTo interface.
+func (f *Field) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, f.compose)
+}
@@ -0,0 +1,136 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldInstruction(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p *Paragraph) *Field
+		want  string
+	}{
+		{
+			name:  "page",
+			build: func(p *Paragraph) *Field { return p.AddField(FieldPage, FieldOptions{}) },
+			want:  "PAGE \\* MERGEFORMAT",
+		},
+		{
+			name:  "page with number format",
+			build: func(p *Paragraph) *Field { return p.AddField(FieldPage, FieldOptions{Format: FieldFormatRoman}) },
+			want:  "PAGE \\* roman \\* MERGEFORMAT",
+		},
+		{
+			name:  "date picture with quotes",
+			build: func(p *Paragraph) *Field { return p.AddField(FieldDate, FieldOptions{Format: `d "of" MMMM`}) },
+			want:  `DATE \@ "d \"of\" MMMM" \* MERGEFORMAT`,
+		},
+		{
+			name: "hyperlink with backslashes and quotes",
+			build: func(p *Paragraph) *Field {
+				return p.AddHyperlink("file", `C:\docs\"a".rtf`, 12, FontArial, ColorBlue)
+			},
+			want: `HYPERLINK "C:\\docs\\\"a\".rtf"`,
+		},
+		{
+			name: "internal link",
+			build: func(p *Paragraph) *Field {
+				return p.AddInternalLink("see", "results", 12, FontArial, ColorBlue)
+			},
+			want: `HYPERLINK \l "results"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.build(NewDocument().AddParagraph())
+			if got := f.instruction(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldPlaceholder(t *testing.T) {
+	defer func(now func() time.Time) { timeNow = now }(timeNow)
+	timeNow = func() time.Time { return time.Date(2024, 3, 5, 14, 7, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name  string
+		build func(p *Paragraph) *Field
+		want  string
+	}{
+		{"page", func(p *Paragraph) *Field { return p.AddField(FieldPage, FieldOptions{}) }, "1"},
+		{"date", func(p *Paragraph) *Field { return p.AddField(FieldDate, FieldOptions{}) }, "05.03.2024"},
+		{"time", func(p *Paragraph) *Field { return p.AddField(FieldTime, FieldOptions{}) }, "14:07"},
+		{"placeholder", func(p *Paragraph) *Field {
+			return p.AddField(FieldDate, FieldOptions{Placeholder: "today"})
+		}, "today"},
+		{"hyperlink text", func(p *Paragraph) *Field {
+			return p.AddHyperlink("site", "https://example.com", 12, FontArial, ColorBlue)
+		}, "site"},
+		{"hyperlink without text", func(p *Paragraph) *Field {
+			return p.AddHyperlink("", "https://example.com", 12, FontArial, ColorBlue)
+		}, "https://example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.build(NewDocument().AddParagraph()).content; got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldCompose(t *testing.T) {
+	doc := NewDocument()
+	doc.AddParagraph().AddHyperlink("", `C:\a.rtf`, 12, FontArial, ColorBlue)
+	want := `{\field{\*\fldinst
+{\f2\fs24\cf2\ul HYPERLINK "C:\\\\a.rtf"}}{\fldrslt
+{\f2\fs24\cf2\ul C:\\a.rtf}}}`
+	if body := exportBody(doc); !strings.Contains(body, want) {
+		t.Errorf("%q not found in\n%s", want, body)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p *Paragraph)
+		want  string
+	}{
+		{
+			name:  "bookmark",
+			build: func(p *Paragraph) { p.AddBookmark("results") },
+			want:  "{\\*\\bkmkstart results}{\\*\\bkmkend results}",
+		},
+		{
+			name:  "bookmark name is escaped",
+			build: func(p *Paragraph) { p.AddBookmark("a{b}") },
+			want:  "{\\*\\bkmkstart a\\{b\\}}{\\*\\bkmkend a\\{b\\}}",
+		},
+		{
+			name:  "hyperlink is underlined",
+			build: func(p *Paragraph) { p.AddHyperlink("site", "https://example.com", 10, FontArial, ColorRed) },
+			want:  "{\\f2\\fs20\\cf7\\ul HYPERLINK \"https://example.com\"}}{\\fldrslt\n{\\f2\\fs20\\cf7\\ul site}}}",
+		},
+		{
+			name: "internal link refers to bookmark",
+			build: func(p *Paragraph) {
+				p.AddBookmark("results")
+				p.AddInternalLink("see", "results", 12, FontArial, ColorBlue)
+			},
+			want: "{\\*\\bkmkend results}\n{\\field{\\*\\fldinst\n{\\f2\\fs24\\cf2\\ul HYPERLINK \\\\l \"results\"}}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc.AddParagraph())
+			if body := exportBody(doc); !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+		})
+	}
+}
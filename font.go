@@ -5,6 +5,15 @@ import (
 	"strings"
 )
 
+// defaultFontTable holds fonts of the new Document
+var defaultFontTable = FontTable{
+	{family: "roman", charset: 0, prq: 2, name: "Times New Roman", code: FontTimesNewRoman},
+	{family: "roman", charset: 2, prq: 2, name: "Symbol", code: FontSymbol},
+	{family: "swiss", charset: 0, prq: 2, name: "Arial", code: FontArial},
+	{family: "swiss", charset: 0, prq: 2, name: "Comic Sans MS", code: FontComicSansMS},
+	{family: "modern", charset: 128, prq: 1, name: "Curier New", code: FontCourierNew},
+}
+
 // AddFont returns font instance
 func (ft *FontTable) AddFont(family string, charset int, prq int, name string, code string) *FontTable {
 	if prq == 0 {
@@ -15,7 +24,7 @@ func (ft *FontTable) AddFont(family string, charset int, prq int, name string, c
 }
 
 func (f *font) encode() string {
-	return fmt.Sprintf("\\f%s\\fprq%d\\fcharset%d %s;", f.family, f.prq, f.charset, f.name)
+	return fmt.Sprintf("\\f%s\\fprq%d\\fcharset%d %s;", f.family, f.prq, f.charset, escapeName(f.name))
 
 }
 
@@ -0,0 +1,154 @@
+package rtfdoc
+
+import (
+	"fmt"
+	"io"
+)
+
+// AddFootnote adds footnote reference mark into Paragraph and returns footnote, which content is placed at the bottom of the page
+func (par *Paragraph) AddFootnote() *Footnote {
+	return par.addNote(false)
+}
+
+// AddEndnote adds endnote reference mark into Paragraph and returns endnote, which content is placed at the end of the document or section
+func (par *Paragraph) AddEndnote() *Footnote {
+	return par.addNote(true)
+}
+
+func (par *Paragraph) addNote(isEndnote bool) *Footnote {
+	fn := Footnote{
+		isEndnote:    isEndnote,
+		markFontSize: defaultFontSize / 2,
+		maxWidth:     par.maxWidth,
+		generalSettings: generalSettings{
+			colorTable: par.colorTable,
+			fontColor:  par.fontColor,
+			styleSheet: par.styleSheet,
+			listTable:  par.listTable,
+		},
+	}
+	par.content = append(par.content, &fn)
+	return &fn
+}
+
+// SetMarkFontSize sets font size of the reference mark
+func (fn *Footnote) SetMarkFontSize(fontSize int) *Footnote {
+	fn.markFontSize = fontSize
+	return fn
+}
+
+// AddParagraph returns new Paragraph of the footnote
+func (fn *Footnote) AddParagraph() *Paragraph {
+	p := newParagraph(fn.generalSettings, fn.maxWidth)
+	p.align = AlignLeft
+	fn.content = append(fn.content, p)
+	return p
+}
+
+func (fn Footnote) compose(w *rtfWriter) {
+	mark := fmt.Sprintf("{\\super\\fs%d\\chftn}", fn.markFontSize*2)
+	w.printf("\n%s{\\footnote", mark)
+	if fn.isEndnote {
+		w.WriteString("\\ftnalt")
+	}
+	// Mark in the note text is merged with the first paragraph, as \pard doesn't end paragraph
+	w.printf("\\pard\\plain %s", mark)
+	for i, p := range fn.content {
+		if i == len(fn.content)-1 {
+			last := *p
+			last.isLast = true
+			last.compose(w)
+			continue
+		}
+		p.compose(w)
+	}
+	w.WriteString("}")
+}
+
+// WriteTo streams Footnote to w. It implements io.WriterTo interface.
+func (fn *Footnote) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, fn.compose)
+}
+
+// getNoteFormat returns rtf suffix of the note numbering format
+func getNoteFormat(format string) string {
+	switch format {
+	case ListFormatLowerLetter:
+		return "alc"
+	case ListFormatUpperLetter:
+		return "auc"
+	case ListFormatLowerRoman:
+		return "rlc"
+	case ListFormatUpperRoman:
+		return "ruc"
+	case ListFormatBullet:
+		// Chicago Manual of Style symbols (*, †, ‡, §)
+		return "chi"
+	default:
+		return "ar"
+	}
+}
+
+// SetFootnoteNumbering sets footnote number format (ListFormatDecimal, ListFormatLowerRoman, etc.
+// ListFormatBullet stands for *, †, ‡ symbols)
+func (doc *Document) SetFootnoteNumbering(format string) *Document {
+	doc.footnoteFormat = format
+	return doc
+}
+
+// SetEndnoteNumbering sets endnote number format (ListFormatDecimal, ListFormatLowerRoman, etc.)
+func (doc *Document) SetEndnoteNumbering(format string) *Document {
+	doc.endnoteFormat = format
+	return doc
+}
+
+// SetFootnoteRestart sets footnote numbering restart (NoteRestartContinuous, NoteRestartSection, NoteRestartPage)
+func (doc *Document) SetFootnoteRestart(restart string) *Document {
+	for _, i := range []string{NoteRestartContinuous, NoteRestartSection, NoteRestartPage} {
+		if restart == i {
+			doc.footnoteRestart = i
+		}
+	}
+	return doc
+}
+
+// SetEndnoteRestart sets endnote numbering restart (NoteRestartContinuous, NoteRestartSection)
+func (doc *Document) SetEndnoteRestart(restart string) *Document {
+	for _, i := range []string{NoteRestartContinuous, NoteRestartSection} {
+		if restart == i {
+			doc.endnoteRestart = i
+		}
+	}
+	return doc
+}
+
+// SetEndnotePlacement sets endnotes placement (EndnotesAtDocumentEnd, EndnotesAtSectionEnd)
+func (doc *Document) SetEndnotePlacement(placement string) *Document {
+	for _, i := range []string{EndnotesAtDocumentEnd, EndnotesAtSectionEnd} {
+		if placement == i {
+			doc.endnotePlacement = i
+		}
+	}
+	return doc
+}
+
+func (ns noteSettings) getNotesProperties() string {
+	// Document may contain both footnotes and endnotes
+	res := "\n\\fet2"
+	if ns.footnoteFormat != "" {
+		res += fmt.Sprintf("\\ftnn%s", getNoteFormat(ns.footnoteFormat))
+	}
+	if ns.footnoteRestart != "" {
+		res += fmt.Sprintf("\\ftn%s", ns.footnoteRestart)
+	}
+	if ns.endnoteFormat != "" {
+		res += fmt.Sprintf("\\aftnn%s", getNoteFormat(ns.endnoteFormat))
+	}
+	if ns.endnoteRestart != "" {
+		res += fmt.Sprintf("\\aftn%s", ns.endnoteRestart)
+	}
+	if ns.endnotePlacement != "" {
+		res += fmt.Sprintf("\\%s", ns.endnotePlacement)
+	}
+	return res
+}
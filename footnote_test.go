@@ -0,0 +1,91 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFootnote(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(p *Paragraph)
+		want    string
+		notWant string
+	}{
+		{
+			name: "footnote",
+			build: func(p *Paragraph) {
+				p.AddFootnote().AddParagraph().AddText("note", 10, FontArial, ColorBlack)
+			},
+			want:    "{\\super\\fs24\\chftn}{\\footnote\\pard\\plain {\\super\\fs24\\chftn}",
+			notWant: "\\ftnalt",
+		},
+		{
+			name: "endnote",
+			build: func(p *Paragraph) {
+				p.AddEndnote().AddParagraph().AddText("note", 10, FontArial, ColorBlack)
+			},
+			want: "{\\super\\fs24\\chftn}{\\footnote\\ftnalt\\pard\\plain {\\super\\fs24\\chftn}",
+		},
+		{
+			name: "mark font size",
+			build: func(p *Paragraph) {
+				p.AddFootnote().SetMarkFontSize(8).AddParagraph().AddText("note", 10, FontArial, ColorBlack)
+			},
+			want: "{\\super\\fs16\\chftn}{\\footnote\\pard\\plain {\\super\\fs16\\chftn}",
+		},
+		{
+			name: "last note paragraph doesn't end with par",
+			build: func(p *Paragraph) {
+				fn := p.AddFootnote()
+				fn.AddParagraph().AddText("first", 10, FontArial, ColorBlack)
+				fn.AddParagraph().AddText("last", 10, FontArial, ColorBlack)
+			},
+			want:    "first}}\\par",
+			notWant: "last}}\\par",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc.AddParagraph())
+			body := exportBody(doc)
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+			if tt.notWant != "" && strings.Contains(body, tt.notWant) {
+				t.Errorf("unexpected %q in\n%s", tt.notWant, body)
+			}
+		})
+	}
+}
+
+func TestNotesProperties(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(doc *Document)
+		want  string
+	}{
+		{"default", func(doc *Document) {}, "\\fet2"},
+		{"footnote numbering", func(doc *Document) {
+			doc.SetFootnoteNumbering(ListFormatLowerRoman).SetFootnoteRestart(NoteRestartPage)
+		}, "\\fet2\\ftnnrlc\\ftnrstpg"},
+		{"symbol numbering", func(doc *Document) { doc.SetFootnoteNumbering(ListFormatBullet) }, "\\fet2\\ftnnchi"},
+		{"endnote numbering", func(doc *Document) {
+			doc.SetEndnoteNumbering(ListFormatUpperLetter).SetEndnoteRestart(NoteRestartSection).
+				SetEndnotePlacement(EndnotesAtSectionEnd)
+		}, "\\fet2\\aftnnauc\\aftnrestart\\aendnotes"},
+		{"unknown settings are ignored", func(doc *Document) {
+			doc.SetEndnoteRestart(NoteRestartPage).SetFootnoteRestart("unknown").SetEndnotePlacement("unknown")
+		}, "\\fet2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			if got := doc.getNotesProperties(); got != "\n"+tt.want {
+				t.Errorf("got %q, want %q", got, "\n"+tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/therox/rtf-doc
 
-go 1.14
+go 1.16
 
 require github.com/google/pprof v0.0.0-20190208070709-b421f19a5c07 // indirect
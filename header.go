@@ -1,10 +1,5 @@
 package rtfdoc
 
-import (
-	"fmt"
-	"strings"
-)
-
 func getDefaultHeader() header {
 	return header{
 		version: "1",
@@ -13,15 +8,19 @@ func getDefaultHeader() header {
 	}
 }
 
-func (h header) compose() string {
-	var res strings.Builder
-	res.WriteString(fmt.Sprintf("\\rtf%s\\%s\\deff%s", h.version, h.charSet, h.deff))
+func (h header) compose(w *rtfWriter) {
+	w.printf("\\rtf%s\\%s\\deff%s", h.version, h.charSet, h.deff)
 
 	if h.fontColor != nil {
-		res.WriteString(fmt.Sprintf("\n{\\fonttbl;%s}", h.fontColor.encode()))
+		w.printf("\n{\\fonttbl;%s}", h.fontColor.encode())
 	}
 	if h.colorTable != nil {
-		res.WriteString(fmt.Sprintf("\n{\\colortbl;%s}", h.colorTable.encode()))
+		w.printf("\n{\\colortbl;%s}", h.colorTable.encode())
+	}
+	if h.styleSheet != nil && len(*h.styleSheet) > 0 {
+		w.printf("\n{\\stylesheet%s}", h.styleSheet.encode())
+	}
+	if h.listTable != nil && len(*h.listTable) > 0 {
+		w.printf("\n%s", h.listTable.encode())
 	}
-	return res.String()
 }
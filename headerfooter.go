@@ -0,0 +1,117 @@
+package rtfdoc
+
+import (
+	"io"
+)
+
+// AddHeader returns page header of the kind (HeaderFooterAll, HeaderFooterFirst, HeaderFooterLeft, HeaderFooterRight).
+// Header of the same kind is created once, repeated calls return it.
+func (doc *Document) AddHeader(kind string) *HeaderFooter {
+	return doc.addHeaderFooter(false, kind)
+}
+
+// AddFooter returns page footer of the kind (HeaderFooterAll, HeaderFooterFirst, HeaderFooterLeft, HeaderFooterRight).
+// Footer of the same kind is created once, repeated calls return it.
+func (doc *Document) AddFooter(kind string) *HeaderFooter {
+	return doc.addHeaderFooter(true, kind)
+}
+
+func (doc *Document) addHeaderFooter(isFooter bool, kind string) *HeaderFooter {
+	hf, headers := getHeaderFooter(doc.headers, isFooter, kind, doc.generalSettings, doc.getPageWidth())
+	doc.headers = headers
+	return hf
+}
+
+// getHeaderFooter looks for header or footer of the kind in headers and creates it, if there is no one
+func getHeaderFooter(headers []*HeaderFooter, isFooter bool, kind string, gs generalSettings, maxWidth int) (*HeaderFooter, []*HeaderFooter) {
+	if !isHeaderFooterKind(kind) {
+		kind = HeaderFooterAll
+	}
+	for _, hf := range headers {
+		if hf.isFooter == isFooter && hf.kind == kind {
+			return hf, headers
+		}
+	}
+	hf := HeaderFooter{
+		isFooter: isFooter,
+		kind:     kind,
+		maxWidth: maxWidth,
+		generalSettings: generalSettings{
+			colorTable: gs.colorTable,
+			fontColor:  gs.fontColor,
+			styleSheet: gs.styleSheet,
+			listTable:  gs.listTable,
+		},
+	}
+	return &hf, append(headers, &hf)
+}
+
+func isHeaderFooterKind(kind string) bool {
+	for _, i := range []string{HeaderFooterAll, HeaderFooterFirst, HeaderFooterLeft, HeaderFooterRight} {
+		if kind == i {
+			return true
+		}
+	}
+	return false
+}
+
+// AddParagraph returns new Paragraph of the header or footer
+func (hf *HeaderFooter) AddParagraph() *Paragraph {
+	p := newParagraph(hf.generalSettings, hf.maxWidth)
+	hf.content = append(hf.content, p)
+	return p
+}
+
+// AddTable returns new Table of the header or footer
+func (hf *HeaderFooter) AddTable() *Table {
+	t := newTable(hf.generalSettings, hf.maxWidth)
+	hf.content = append(hf.content, t)
+	return t
+}
+
+// AddPicture adds new Paragraph with the picture to the header or footer
+func (hf *HeaderFooter) AddPicture(source []byte, format string) *Picture {
+	return hf.AddParagraph().AddPicture(source, format)
+}
+
+func (hf HeaderFooter) compose(w *rtfWriter) {
+	destination := "header"
+	if hf.isFooter {
+		destination = "footer"
+	}
+	w.printf("\n{\\%s%s", destination, hf.kind)
+	for _, c := range hf.content {
+		w.WriteString("\n")
+		c.compose(w)
+	}
+	w.WriteString("\n}")
+}
+
+// WriteTo streams HeaderFooter to w. It implements io.WriterTo interface.
+func (hf *HeaderFooter) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, hf.compose)
+}
+
+// composeHeaders writes page settings required by headers and footers and headers themselves
+func composeHeaders(w *rtfWriter, headers []*HeaderFooter) {
+	for _, hf := range headers {
+		if hf.kind == HeaderFooterFirst {
+			// Different first page
+			w.WriteString("\n\\titlepg")
+			break
+		}
+	}
+	for _, hf := range headers {
+		hf.compose(w)
+	}
+}
+
+// hasFacingPages reports whether there are headers or footers for left and right pages
+func hasFacingPages(headers []*HeaderFooter) bool {
+	for _, hf := range headers {
+		if hf.kind == HeaderFooterLeft || hf.kind == HeaderFooterRight {
+			return true
+		}
+	}
+	return false
+}
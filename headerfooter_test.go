@@ -0,0 +1,80 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeaderFooter(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(doc *Document)
+		want    []string
+		notWant []string
+		groups  int
+	}{
+		{
+			name: "header and footer of all pages",
+			build: func(doc *Document) {
+				doc.AddHeader(HeaderFooterAll).AddParagraph().AddText("head", 12, FontArial, ColorBlack)
+				doc.AddFooter(HeaderFooterAll).AddParagraph().AddText("foot", 12, FontArial, ColorBlack)
+			},
+			want:    []string{"{\\header\n", "head}", "{\\footer\n", "foot}"},
+			notWant: []string{"\\titlepg", "\\facingp"},
+			groups:  2,
+		},
+		{
+			name: "first page header",
+			build: func(doc *Document) {
+				doc.AddHeader(HeaderFooterFirst).AddParagraph().AddText("first", 12, FontArial, ColorBlack)
+			},
+			want:    []string{"\\titlepg", "{\\headerf\n"},
+			notWant: []string{"\\facingp"},
+		},
+		{
+			name: "left and right page footers",
+			build: func(doc *Document) {
+				doc.AddFooter(HeaderFooterLeft).AddParagraph().AddText("left", 12, FontArial, ColorBlack)
+				doc.AddFooter(HeaderFooterRight).AddParagraph().AddText("right", 12, FontArial, ColorBlack)
+			},
+			want:    []string{"\\facingp", "{\\footerl\n", "{\\footerr\n"},
+			notWant: []string{"\\titlepg"},
+		},
+		{
+			name: "unknown kind is all pages",
+			build: func(doc *Document) {
+				doc.AddHeader("unknown").AddParagraph().AddText("head", 12, FontArial, ColorBlack)
+			},
+			want: []string{"{\\header\n"},
+		},
+		{
+			name: "header of the same kind is created once",
+			build: func(doc *Document) {
+				doc.AddHeader(HeaderFooterAll).AddParagraph().AddText("one", 12, FontArial, ColorBlack)
+				doc.AddHeader(HeaderFooterAll).AddParagraph().AddText("two", 12, FontArial, ColorBlack)
+			},
+			want:   []string{"one}", "two}"},
+			groups: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			s := string(doc.Export())
+			for _, w := range tt.want {
+				if !strings.Contains(s, w) {
+					t.Errorf("%q not found in\n%s", w, s)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(s, w) {
+					t.Errorf("unexpected %q in\n%s", w, s)
+				}
+			}
+			if n := strings.Count(s, "{\\header") + strings.Count(s, "{\\footer"); tt.groups > 0 && n != tt.groups {
+				t.Errorf("got %d headers and footers, want %d", n, tt.groups)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package rtfdoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// listLevelsCount is number of levels in rtf list
+const listLevelsCount = 9
+
+// listHangingIndent is distance between list item number and its text
+const listHangingIndent = 360
+
+// NewListTable returns new list table for Document
+func (doc *Document) NewListTable() *ListTable {
+	lt := ListTable{}
+	doc.header.listTable = &lt
+	return &lt
+}
+
+// AddList returns new list of kind (ListNumbered, ListBulleted), which items are added to Document
+func (doc *Document) AddList(kind string) *List {
+	return newList(kind, doc.generalSettings, &doc.content, doc.maxWidth)
+}
+
+// AddList returns new list of kind (ListNumbered, ListBulleted), which items are added to Section
+func (s *Section) AddList(kind string) *List {
+	return newList(kind, s.generalSettings, &s.content, s.maxWidth)
+}
+
+func newList(kind string, gs generalSettings, content *[]documentItem, maxWidth int) *List {
+	l := List{
+		kind:     ListNumbered,
+		content:  content,
+		maxWidth: maxWidth,
+		generalSettings: generalSettings{
+			colorTable: gs.colorTable,
+			fontColor:  gs.fontColor,
+			styleSheet: gs.styleSheet,
+			listTable:  gs.listTable,
+		},
+	}
+	if kind == ListBulleted {
+		l.kind = ListBulleted
+	}
+	numberFormats := []string{ListFormatDecimal, ListFormatLowerLetter, ListFormatLowerRoman}
+	for i := range l.levels {
+		l.levels[i] = listLevel{
+			format: numberFormats[i%len(numberFormats)],
+			start:  1,
+			indent: (i + 1) * 720,
+		}
+		if l.kind == ListBulleted {
+			l.levels[i].format = ListFormatBullet
+		}
+	}
+	*l.listTable = append(*l.listTable, &l)
+	return &l
+}
+
+// id returns list number in document list table
+func (l *List) id() int {
+	for i, item := range *l.listTable {
+		if item == l {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func isListLevel(level int) bool {
+	return level >= 0 && level < listLevelsCount
+}
+
+// SetLevelFormat sets number format of the list level (0 - 8)
+func (l *List) SetLevelFormat(level int, format string) *List {
+	if !isListLevel(level) {
+		return l
+	}
+	for _, i := range []string{
+		ListFormatDecimal,
+		ListFormatUpperRoman,
+		ListFormatLowerRoman,
+		ListFormatUpperLetter,
+		ListFormatLowerLetter,
+		ListFormatBullet,
+	} {
+		if format == i {
+			l.levels[level].format = i
+		}
+	}
+	return l
+}
+
+// SetLevelStart sets start number of the list level (0 - 8)
+func (l *List) SetLevelStart(level int, start int) *List {
+	if isListLevel(level) {
+		l.levels[level].start = start
+	}
+	return l
+}
+
+// SetLevelIndent sets left indent in twips of the list level (0 - 8)
+func (l *List) SetLevelIndent(level int, indent int) *List {
+	if isListLevel(level) {
+		l.levels[level].indent = indent
+	}
+	return l
+}
+
+// AddItem adds list item paragraph of the level (0 - 8)
+func (l *List) AddItem(level int) *Paragraph {
+	if !isListLevel(level) {
+		level = 0
+	}
+	p := newParagraph(l.generalSettings, l.maxWidth)
+	p.align = AlignLeft
+	p.listID = l.id()
+	p.listLevel = level
+	p.indentLeftIndent = l.levels[level].indent
+	p.indentFirstLine = -listHangingIndent
+	*l.content = append(*l.content, p)
+	return p
+}
+
+// getNumberFormat returns rtf \levelnfc value of the format
+func getNumberFormat(format string) int {
+	switch format {
+	case ListFormatUpperRoman:
+		return 1
+	case ListFormatLowerRoman:
+		return 2
+	case ListFormatUpperLetter:
+		return 3
+	case ListFormatLowerLetter:
+		return 4
+	case ListFormatBullet:
+		return 23
+	default:
+		return 0
+	}
+}
+
+func (lvl listLevel) encode(level int) string {
+	nfc := getNumberFormat(lvl.format)
+	res := fmt.Sprintf("{\\listlevel\\levelnfc%d\\levelnfcn%d\\leveljc0\\leveljcn0\\levelfollow0\\levelstartat%d\\levelspace0\\levelindent0",
+		nfc, nfc, lvl.start)
+	if lvl.format == ListFormatBullet {
+		res += "{\\leveltext\\'01\\u8226 ?;}{\\levelnumbers;}"
+	} else {
+		// Level text is the level number followed by dot
+		res += fmt.Sprintf("{\\leveltext\\'02\\'%02x.;}{\\levelnumbers\\'01;}", level)
+	}
+	return res + fmt.Sprintf("\\fi-%d\\li%d}", listHangingIndent, lvl.indent)
+}
+
+func (l List) encode(id int) string {
+	var res strings.Builder
+	res.WriteString(fmt.Sprintf("{\\list\\listtemplateid%d", id))
+	for i, lvl := range l.levels {
+		res.WriteString(lvl.encode(i))
+	}
+	res.WriteString(fmt.Sprintf("{\\listname ;}\\listid%d}", id))
+	return res.String()
+}
+
+func (lt ListTable) encode() string {
+	var res strings.Builder
+	res.WriteString("{\\*\\listtable")
+	for i := range lt {
+		res.WriteString(lt[i].encode(i + 1))
+	}
+	res.WriteString("}\n{\\*\\listoverridetable")
+	for i := range lt {
+		res.WriteString(fmt.Sprintf("{\\listoverride\\listid%d\\listoverridecount0\\ls%d}", i+1, i+1))
+	}
+	res.WriteString("}")
+	return res.String()
+}
@@ -0,0 +1,99 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(doc *Document)
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "numbered list",
+			build: func(doc *Document) {
+				l := doc.AddList(ListNumbered)
+				l.AddItem(0).AddText("one", 12, FontArial, ColorBlack)
+				l.AddItem(1).AddText("two", 12, FontArial, ColorBlack)
+			},
+			want: []string{
+				"{\\list\\listtemplateid1{\\listlevel\\levelnfc0\\levelnfcn0\\leveljc0\\leveljcn0\\levelfollow0\\levelstartat1\\levelspace0\\levelindent0{\\leveltext\\'02\\'00.;}{\\levelnumbers\\'01;}\\fi-360\\li720}",
+				"{\\listlevel\\levelnfc4\\levelnfcn4\\leveljc0\\leveljcn0\\levelfollow0\\levelstartat1\\levelspace0\\levelindent0{\\leveltext\\'02\\'01.;}{\\levelnumbers\\'01;}\\fi-360\\li1440}",
+				"{\\listoverride\\listid1\\listoverridecount0\\ls1}",
+				"\\ls1\\ilvl0 ",
+				"\\ls1\\ilvl1 ",
+			},
+		},
+		{
+			name: "bulleted list",
+			build: func(doc *Document) {
+				doc.AddList(ListBulleted).AddItem(0).AddText("one", 12, FontArial, ColorBlack)
+			},
+			want: []string{"\\levelnfc23\\levelnfcn23", "{\\leveltext\\'01\\u8226 ?;}{\\levelnumbers;}"},
+		},
+		{
+			name: "unknown kind is numbered",
+			build: func(doc *Document) {
+				doc.AddList("unknown").AddItem(0)
+			},
+			want:    []string{"\\levelnfc0\\levelnfcn0"},
+			notWant: []string{"\\levelnfc23"},
+		},
+		{
+			name: "level settings",
+			build: func(doc *Document) {
+				doc.AddList(ListNumbered).
+					SetLevelFormat(0, ListFormatUpperRoman).SetLevelStart(0, 5).SetLevelIndent(0, 1000).
+					SetLevelFormat(0, "unknown").SetLevelFormat(listLevelsCount, ListFormatBullet).
+					AddItem(0)
+			},
+			want:    []string{"\\levelnfc1\\levelnfcn1\\leveljc0\\leveljcn0\\levelfollow0\\levelstartat5", "\\fi-360\\li1000}"},
+			notWant: []string{"\\levelnfc23"},
+		},
+		{
+			name: "every list has own id",
+			build: func(doc *Document) {
+				doc.AddList(ListNumbered).AddItem(0)
+				s := doc.AddSection()
+				s.AddList(ListBulleted).AddItem(0)
+			},
+			want: []string{"\\listid2}", "\\ls1\\ilvl0 ", "\\ls2\\ilvl0 "},
+		},
+		{
+			name: "item of unknown level is first level item",
+			build: func(doc *Document) {
+				doc.AddList(ListNumbered).AddItem(-1)
+			},
+			want: []string{"\\ls1\\ilvl0 "},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			s := string(doc.Export())
+			for _, w := range tt.want {
+				if !strings.Contains(s, w) {
+					t.Errorf("%q not found in\n%s", w, s)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(s, w) {
+					t.Errorf("unexpected %q in\n%s", w, s)
+				}
+			}
+		})
+	}
+}
+
+func TestListItemIndent(t *testing.T) {
+	doc := NewDocument()
+	l := doc.AddList(ListNumbered).SetLevelIndent(2, 2000)
+	p := l.AddItem(2)
+	if p.indentLeftIndent != 2000 || p.indentFirstLine != -listHangingIndent || p.align != AlignLeft {
+		t.Errorf("got indent %d, first line %d, align %q", p.indentLeftIndent, p.indentFirstLine, p.align)
+	}
+}
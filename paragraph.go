@@ -2,23 +2,30 @@ package rtfdoc
 
 import (
 	"fmt"
-	"strings"
+	"io"
 )
 
 // AddParagraph return new instance of Paragraph.
 func (doc *Document) AddParagraph() *Paragraph {
+	p := newParagraph(doc.generalSettings, doc.maxWidth)
+	doc.content = append(doc.content, p)
+	return p
+}
+
+// newParagraph returns Paragraph with default settings, which fits into allowedWidth
+func newParagraph(gs generalSettings, allowedWidth int) *Paragraph {
 	p := Paragraph{
 		align:   AlignCenter,
-		indent:  "\\fl360",
 		content: nil,
 		generalSettings: generalSettings{
-			colorTable: doc.colorTable,
-			fontColor:  doc.fontColor,
+			colorTable: gs.colorTable,
+			fontColor:  gs.fontColor,
+			styleSheet: gs.styleSheet,
+			listTable:  gs.listTable,
 		},
-		allowedWidth: doc.maxWidth,
+		allowedWidth: allowedWidth,
 	}
 	p.updateMaxWidth()
-	doc.content = append(doc.content, &p)
 	return &p
 }
 
@@ -27,27 +34,290 @@ func (par *Paragraph) updateMaxWidth() *Paragraph {
 	return par
 }
 
-func (par Paragraph) compose() string {
-	var res strings.Builder
+func (par Paragraph) compose(w *rtfWriter) {
 	indentStr := fmt.Sprintf("\\fi%d \\li%d \\ri%d",
 		par.indentFirstLine,
 		par.indentLeftIndent,
 		par.indentRightIndent)
-	res.WriteString(fmt.Sprintf("\n\\pard \\q%s %s {", par.align, indentStr))
-	if par.isTable {
-		res.WriteString("\\intbl")
+	indentStr += par.getSpacing() + par.getTabStops()
+	styleStr, charStr := "", ""
+	var parStyle *Style
+	if i, s := par.styleSheet.find(par.style); s != nil {
+		r := s.resolve()
+		parStyle = &r
+		styleStr = fmt.Sprintf("\\s%d", i)
+		if r.outlineLevel > 0 {
+			styleStr += fmt.Sprintf("\\outlinelevel%d", r.outlineLevel-1)
+		}
+		styleStr += " "
+		charStr = r.charProperties()
+	}
+	if par.listID > 0 {
+		styleStr += fmt.Sprintf("\\ls%d\\ilvl%d ", par.listID, par.listLevel)
+	}
+	w.printf("\n\\pard %s\\q%s %s ", styleStr, par.align, indentStr)
+	par.composeBorders(w)
+	if par.isTable && par.nestLevel > 1 {
+		w.printf("\\intbl\\itap%d ", par.nestLevel)
+	}
+	w.WriteString("{")
+	if par.isTable && par.nestLevel <= 1 {
+		w.WriteString("\\intbl")
 	}
+	// Character formatting of the style is applied to the whole paragraph content
+	w.WriteString(charStr)
 	// res += fmt.Sprintf(" \\q%s", par.align)
 
+	prevStyle := w.parStyle
+	w.parStyle = parStyle
 	for _, c := range par.content {
-		res.WriteString(c.compose())
+		c.compose(w)
 	}
+	w.parStyle = prevStyle
 	// res += "\n\\par}"
-	res.WriteString("}")
-	if !par.isTable {
-		res.WriteString("\\par")
+	w.WriteString("}")
+	if !par.isTable && !par.isLast {
+		w.WriteString("\\par")
+	}
+}
+
+// WriteTo streams Paragraph to w. It implements io.WriterTo interface.
+func (par *Paragraph) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, par.compose)
+}
+
+func (ps paragraphSpacing) getSpacing() string {
+	var res string
+	if ps.spaceBefore != 0 {
+		res += fmt.Sprintf("\\sb%d", ps.spaceBefore)
+	}
+	if ps.spaceAfter != 0 {
+		res += fmt.Sprintf("\\sa%d", ps.spaceAfter)
+	}
+	if ps.autoSpaceBefore {
+		res += "\\sbauto1"
+	}
+	if ps.autoSpaceAfter {
+		res += "\\saauto1"
+	}
+	if ps.lineSpacing != 0 {
+		mult := 0
+		if ps.lineMultiple {
+			mult = 1
+		}
+		res += fmt.Sprintf("\\sl%d\\slmult%d", ps.lineSpacing, mult)
+	}
+	if ps.keepWithNext {
+		res += "\\keepn"
+	}
+	if ps.keepTogether {
+		res += "\\keep"
+	}
+	if ps.widowControl != "" {
+		res += "\\" + ps.widowControl
+	}
+	if ps.pageBreakBefore {
+		res += "\\pagebb"
+	}
+	if res != "" {
+		res = " " + res
+	}
+	return res
+}
+
+func (par Paragraph) getTabStops() string {
+	var res string
+	for _, ts := range par.tabStops {
+		if ts.align != AlignLeft {
+			res += fmt.Sprintf("\\tq%s", ts.align)
+		}
+		if ts.leader != TabLeaderNone {
+			res += fmt.Sprintf("\\tl%s", ts.leader)
+		}
+		res += fmt.Sprintf("\\tx%d", ts.position)
+	}
+	if res != "" {
+		res = " " + res
+	}
+	return res
+}
+
+// AddTabStop adds tab stop at position in twips with aligning (AlignLeft, AlignCenter, AlignRight, TabAlignDecimal)
+// and leader filling the space before it (TabLeaderNone, TabLeaderDots, etc.)
+func (par *Paragraph) AddTabStop(position int, align string, leader string) *Paragraph {
+	if position < 0 {
+		return par
+	}
+	alignOK, leaderOK := false, false
+	for _, i := range []string{AlignLeft, AlignCenter, AlignRight, TabAlignDecimal} {
+		alignOK = alignOK || align == i
+	}
+	for _, i := range []string{TabLeaderNone, TabLeaderDots, TabLeaderMiddleDots, TabLeaderHyphens, TabLeaderUnderline, TabLeaderThick, TabLeaderEqual} {
+		leaderOK = leaderOK || leader == i
+	}
+	if !alignOK || !leaderOK {
+		return par
+	}
+	par.tabStops = append(par.tabStops, tabStop{position: position, align: align, leader: leader})
+	return par
+}
+
+// SetSpaceBefore sets space before Paragraph in twips
+func (par *Paragraph) SetSpaceBefore(value int) *Paragraph {
+	par.spaceBefore = value
+	return par
+}
+
+// SetSpaceAfter sets space after Paragraph in twips
+func (par *Paragraph) SetSpaceAfter(value int) *Paragraph {
+	par.spaceAfter = value
+	return par
+}
+
+// SetAutoSpacing makes space before and (or) after Paragraph to be chosen by the reader application
+func (par *Paragraph) SetAutoSpacing(before bool, after bool) *Paragraph {
+	par.autoSpaceBefore = before
+	par.autoSpaceAfter = after
+	return par
+}
+
+// SetLineSpacing sets line spacing with rule LineSpacingMultiple (value in 240ths of line, e.g. LineSpacingDouble),
+// LineSpacingExact or LineSpacingAtLeast (value in twips)
+func (par *Paragraph) SetLineSpacing(value int, rule string) *Paragraph {
+	if value <= 0 {
+		return par
 	}
-	return res.String()
+	switch rule {
+	case LineSpacingMultiple:
+		par.lineSpacing, par.lineMultiple = value, true
+	case LineSpacingExact:
+		par.lineSpacing, par.lineMultiple = -value, false
+	case LineSpacingAtLeast:
+		par.lineSpacing, par.lineMultiple = value, false
+	}
+	return par
+}
+
+// SetKeepWithNext keeps Paragraph on the same page with the next one
+func (par *Paragraph) SetKeepWithNext(keep bool) *Paragraph {
+	par.keepWithNext = keep
+	return par
+}
+
+// SetKeepTogether prevents Paragraph from being split by page break
+func (par *Paragraph) SetKeepTogether(keep bool) *Paragraph {
+	par.keepTogether = keep
+	return par
+}
+
+// SetWidowControl turns on or off widow and orphan control of the Paragraph
+func (par *Paragraph) SetWidowControl(control bool) *Paragraph {
+	par.widowControl = "nowidctlpar"
+	if control {
+		par.widowControl = "widctlpar"
+	}
+	return par
+}
+
+// SetPageBreakBefore starts Paragraph from the new page
+func (par *Paragraph) SetPageBreakBefore(pageBreak bool) *Paragraph {
+	par.pageBreakBefore = pageBreak
+	return par
+}
+
+// composeBorders writes Paragraph borders and shading. Consecutive paragraphs with the same borders are drawn as one box.
+func (par Paragraph) composeBorders(w *rtfWriter) {
+	line := par.getBorderLine(sideLeft)
+	isBox := par.borderLeft && par.borderRight && par.borderTop && par.borderBottom
+	for i := sideRight; i <= sideBottom; i++ {
+		isBox = isBox && par.getBorderLine(i) == line
+	}
+	written := false
+	if isBox {
+		composeBorderLine(w, "box", line, par.colorTable)
+		written = true
+	} else {
+		for i, side := range []struct {
+			name string
+			on   bool
+		}{
+			{"l", par.borderLeft},
+			{"r", par.borderRight},
+			{"t", par.borderTop},
+			{"b", par.borderBottom},
+		} {
+			if side.on {
+				composeBorderLine(w, "brdr"+side.name, par.getBorderLine(i), par.colorTable)
+				written = true
+				if par.borderSpace > 0 {
+					w.printf("\\brsp%d", par.borderSpace)
+				}
+			}
+		}
+	}
+	if isBox && par.borderSpace > 0 {
+		w.printf("\\brsp%d", par.borderSpace)
+	}
+
+	for c := range *par.colorTable {
+		if ((*par.colorTable)[c]).name == par.backgroundColor {
+			w.printf("\\cbpat%d", c+1)
+			written = true
+		}
+	}
+	if par.shading > 0 {
+		w.printf("\\shading%d", par.shading*100)
+		written = true
+		for c := range *par.colorTable {
+			if ((*par.colorTable)[c]).name == par.shadingColor {
+				w.printf("\\cfpat%d", c+1)
+			}
+		}
+	}
+	if written {
+		w.WriteString("\n")
+	}
+}
+
+// SetBorder sets box around the Paragraph with style (BorderSingleThickness, etc.), width in twips and color
+func (par *Paragraph) SetBorder(style string, width int, color string) *Paragraph {
+	for _, side := range []string{BorderSideLeft, BorderSideRight, BorderSideTop, BorderSideBottom} {
+		par.SetBorderSide(side, style, width, color)
+	}
+	return par
+}
+
+// SetBorderSide sets border of the Paragraph side (BorderSideLeft, BorderSideRight, BorderSideTop, BorderSideBottom)
+func (par *Paragraph) SetBorderSide(side string, style string, width int, color string) *Paragraph {
+	i := getSideIndex(side)
+	if i < 0 || i >= sideInsideH || !isBorderStyle(style) {
+		return par
+	}
+	par.setSide(i, borderLine{style: style, width: width, color: color})
+	return par
+}
+
+// SetBorderSpace sets space between Paragraph borders and text in twips
+func (par *Paragraph) SetBorderSpace(value int) *Paragraph {
+	if value >= 0 {
+		par.borderSpace = value
+	}
+	return par
+}
+
+// SetBackgroundColor sets Paragraph background color
+func (par *Paragraph) SetBackgroundColor(color string) *Paragraph {
+	par.backgroundColor = color
+	return par
+}
+
+// SetShading sets Paragraph shading pattern of percent (0 - 100) with pattern color
+func (par *Paragraph) SetShading(percent int, color string) *Paragraph {
+	if percent >= 0 && percent <= 100 {
+		par.shading = percent
+		par.shadingColor = color
+	}
+	return par
 }
 
 // SetIndentFirstLine function sets first line indent in twips.
@@ -79,6 +349,7 @@ func (par *Paragraph) SetAlign(align string) *Paragraph {
 	} {
 		if i == align {
 			par.align = i
+			par.alignSet = true
 		}
 	}
 
@@ -0,0 +1,148 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+// paragraphProperties returns properties of the first Document paragraph following its indents
+func paragraphProperties(doc *Document) string {
+	body := exportBody(doc)
+	start := strings.Index(body, "\\ri0") + len("\\ri0")
+	return body[start : start+strings.Index(body[start:], "{")]
+}
+
+func TestParagraphSpacing(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p *Paragraph)
+		want  string
+	}{
+		{"default", func(p *Paragraph) {}, " "},
+		{"space before and after", func(p *Paragraph) { p.SetSpaceBefore(120).SetSpaceAfter(240) }, " \\sb120\\sa240 "},
+		{"auto spacing", func(p *Paragraph) { p.SetAutoSpacing(true, true) }, " \\sbauto1\\saauto1 "},
+		{"line spacing multiple", func(p *Paragraph) { p.SetLineSpacing(LineSpacingDouble, LineSpacingMultiple) }, " \\sl480\\slmult1 "},
+		{"exact line spacing", func(p *Paragraph) { p.SetLineSpacing(300, LineSpacingExact) }, " \\sl-300\\slmult0 "},
+		{"line spacing at least", func(p *Paragraph) { p.SetLineSpacing(300, LineSpacingAtLeast) }, " \\sl300\\slmult0 "},
+		{"invalid line spacing is ignored", func(p *Paragraph) {
+			p.SetLineSpacing(0, LineSpacingExact).SetLineSpacing(300, "unknown")
+		}, " "},
+		{"pagination", func(p *Paragraph) {
+			p.SetKeepWithNext(true).SetKeepTogether(true).SetWidowControl(true).SetPageBreakBefore(true)
+		}, " \\keepn\\keep\\widctlpar\\pagebb "},
+		{"widow control off", func(p *Paragraph) { p.SetWidowControl(false) }, " \\nowidctlpar "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			p := doc.AddParagraph()
+			tt.build(p)
+			p.AddText("x", 12, FontArial, ColorBlack)
+			if got := paragraphProperties(doc); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// Spacing is kept by the reader
+			parsed, err := Parse(strings.NewReader(string(doc.Export())))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := paragraphProperties(parsed); got != tt.want {
+				t.Errorf("parsed: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParagraphBorders(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p *Paragraph)
+		want  string
+	}{
+		{"box", func(p *Paragraph) { p.SetBorder(BorderDouble, 15, ColorRed) }, " \n\\box\\brdrw15\\brdrdb\\brdrcf7\n"},
+		{"box with space", func(p *Paragraph) {
+			p.SetBorder(BorderSingleThickness, 10, ColorBlack).SetBorderSpace(40).SetBorderSpace(-1)
+		}, " \n\\box\\brdrw10\\brdrs\\brdrcf1\\brsp40\n"},
+		{"sides", func(p *Paragraph) {
+			p.SetBorderSide(BorderSideTop, BorderDotted, 10, ColorBlue).SetBorderSide(BorderSideBottom, BorderDouble, 20, ColorRed)
+		}, " \n\\brdrt\\brdrw10\\brdrdot\\brdrcf2\n\\brdrb\\brdrw20\\brdrdb\\brdrcf7\n"},
+		{"different sides are not a box", func(p *Paragraph) {
+			p.SetBorder(BorderSingleThickness, 10, ColorBlack).SetBorderSide(BorderSideLeft, BorderDouble, 10, ColorBlack).SetBorderSpace(20)
+		}, " \n\\brdrl\\brdrw10\\brdrdb\\brdrcf1\\brsp20\n\\brdrr\\brdrw10\\brdrs\\brdrcf1\\brsp20\n\\brdrt\\brdrw10\\brdrs\\brdrcf1\\brsp20\n\\brdrb\\brdrw10\\brdrs\\brdrcf1\\brsp20\n"},
+		{"invalid sides and styles are ignored", func(p *Paragraph) {
+			p.SetBorderSide(BorderSideInsideH, BorderDouble, 10, ColorBlack).SetBorder("unknown", 10, ColorBlack)
+		}, " "},
+		{"background", func(p *Paragraph) { p.SetBackgroundColor(ColorYellow) }, " \\cbpat8\n"},
+		{"shading", func(p *Paragraph) { p.SetShading(25, ColorGray) }, " \\shading2500\\cfpat15\n"},
+		{"invalid shading is ignored", func(p *Paragraph) { p.SetShading(101, ColorGray).SetShading(-1, ColorGray) }, " "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			p := doc.AddParagraph()
+			tt.build(p)
+			p.AddText("x", 12, FontArial, ColorBlack)
+			if got := paragraphProperties(doc); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// Borders and shading are kept by the reader
+			parsed, err := Parse(strings.NewReader(string(doc.Export())))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := paragraphProperties(parsed); got != tt.want {
+				t.Errorf("parsed: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTabStops(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(p *Paragraph)
+		want  string
+	}{
+		{"left", func(p *Paragraph) { p.AddTabStop(1000, AlignLeft, TabLeaderNone) }, " \\tx1000 "},
+		{"aligning and leaders", func(p *Paragraph) {
+			p.AddTabStop(1000, AlignCenter, TabLeaderDots).
+				AddTabStop(2000, AlignRight, TabLeaderUnderline).
+				AddTabStop(3000, TabAlignDecimal, TabLeaderEqual)
+		}, " \\tqc\\tldot\\tx1000\\tqr\\tlul\\tx2000\\tqdec\\tleq\\tx3000 "},
+		{"invalid tab stops are ignored", func(p *Paragraph) {
+			p.AddTabStop(-1, AlignLeft, TabLeaderNone).
+				AddTabStop(1000, AlignJustify, TabLeaderNone).
+				AddTabStop(1000, AlignLeft, "unknown")
+		}, " "},
+		{"tab stops follow spacing", func(p *Paragraph) {
+			p.SetSpaceAfter(100).AddTabStop(500, AlignLeft, TabLeaderHyphens)
+		}, " \\sa100 \\tlhyph\\tx500 "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			p := doc.AddParagraph()
+			tt.build(p)
+			p.AddText("a", 12, FontArial, ColorBlack)
+			p.AddTab()
+			p.AddText("b", 12, FontArial, ColorBlack)
+			if got := paragraphProperties(doc); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// Tab stops are kept by the reader
+			parsed, err := Parse(strings.NewReader(string(doc.Export())))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := paragraphProperties(parsed); got != tt.want {
+				t.Errorf("parsed: got %q, want %q", got, tt.want)
+			}
+			if body := exportBody(parsed); !strings.Contains(body, "a}\n\\tab \n{") {
+				t.Errorf("tab not found in\n%s", body)
+			}
+		})
+	}
+}
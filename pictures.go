@@ -3,8 +3,8 @@ package rtfdoc
 import (
 	"bytes"
 	"encoding/hex"
-	"fmt"
 	"image"
+	"io"
 	"log"
 
 	_ "image/jpeg"
@@ -102,8 +102,8 @@ func (pic *Picture) SetCropBottom(cropB int) *Picture {
 	return pic
 }
 
-func (pic *Picture) compose() string {
-	res := fmt.Sprintf("\n{\\*\\shppict{ \\pict\\picscalex%d\\picscaley%d\\piccropl%d\\piccropr%d\\piccropt%d\\piccropb%d\\picw%d\\pich%d\\picwgoal%d\\pichgoal%d\\%sblip",
+func (pic *Picture) compose(w *rtfWriter) {
+	w.printf("\n{\\*\\shppict{ \\pict\\picscalex%d\\picscaley%d\\piccropl%d\\piccropr%d\\piccropt%d\\piccropb%d\\picw%d\\pich%d\\picwgoal%d\\pichgoal%d\\%sblip",
 		pic.scaleX, pic.scaleY,
 		pic.cropL, pic.cropR, pic.cropT, pic.cropB,
 		pic.width, pic.height,
@@ -111,9 +111,15 @@ func (pic *Picture) compose() string {
 		pic.format,
 	)
 
-	res += "\n" + hex.EncodeToString(pic.src)
-	res += "\n}}"
-	return res
+	w.WriteString("\n")
+	// hex encoder works with small chunks, so picture data is never duplicated in memory
+	_, _ = hex.NewEncoder(w).Write(pic.src)
+	w.WriteString("\n}}")
+}
+
+// WriteTo streams Picture to w. It implements io.WriterTo interface.
+func (pic *Picture) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, pic.compose)
 }
 
 func getImageDimensions(img []byte) (int, int, error) {
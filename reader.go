@@ -0,0 +1,1607 @@
+package rtfdoc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// Parse reads rtf document from r and rebuilds it as Document, so it can be modified
+// with builder functions and exported again. Content following the first \sect is restored as document Sections.
+// Unsupported destinations and control words are skipped.
+func Parse(r io.Reader) (*Document, error) {
+	p := newParser(r)
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.doc, nil
+}
+
+type destination int
+
+const (
+	destBody destination = iota
+	destFontTable
+	destColorTable
+	destPicture
+	destPictureGroup
+	destFieldInstruction
+	destSkip
+)
+
+// Destinations, which content is not a part of document body
+var skippedDestinations = map[string]bool{
+	"info":              true,
+	"stylesheet":        true,
+	"listtable":         true,
+	"listoverridetable": true,
+	"revtbl":            true,
+	"filetbl":           true,
+	"nonshppict":        true,
+	"nonesttables":      true,
+	"shp":               true,
+	"object":            true,
+	"pntext":            true,
+	"listtext":          true,
+	"xe":                true,
+	"tc":                true,
+	"txe":               true,
+	"rxe":               true,
+}
+
+// Simple symbols represented by control words
+var symbolWords = map[string]rune{
+	"emdash":    '—',
+	"endash":    '–',
+	"emspace":   ' ',
+	"enspace":   ' ',
+	"bullet":    '•',
+	"lquote":    '‘',
+	"rquote":    '’',
+	"ldblquote": '“',
+	"rdblquote": '”',
+}
+
+// Simple symbols represented by control symbols
+var symbolChars = map[string]rune{
+	"\\": '\\',
+	"{":  '{',
+	"}":  '}',
+	"~":  ' ',
+	"-":  '­',
+	"_":  '‑',
+}
+
+// defaultFontSize is default font size in half-points
+const defaultFontSize = 24
+
+// charState holds character formatting properties of the group
+type charState struct {
+	bold      bool
+	italic    bool
+	underline bool
+	strike    bool
+	scaps     bool
+	super     bool
+	sub       bool
+	rotated   bool
+	fontSize  int // half-points
+	font      int // number of font in rtf font table
+	color     int // number of color in rtf color table
+}
+
+type groupState struct {
+	chars      charState
+	dest       destination
+	uc         int         // number of fallback characters following \uN
+	ignorable  bool        // group starts with \*
+	wordsCount int         // number of control words in the group
+	endContent func()      // completes container started by the group
+	field      *fieldState // field the group belongs to
+}
+
+// fieldState holds field being parsed, its result is the paragraph content following start
+type fieldState struct {
+	instruction []rune
+	par         *Paragraph
+	start       int
+}
+
+type parState struct {
+	align             string
+	indentFirstLine   int
+	indentLeftIndent  int
+	indentRightIndent int
+	inTable           bool
+	paragraphSpacing
+	borderDefs
+	isBox           bool
+	borderSpace     int
+	backgroundColor int
+	shading         int
+	shadingColor    int
+	tabStops        []tabStop
+	tab             tabStop // tab stop being defined
+}
+
+type borderDef struct {
+	on    bool
+	style string
+	width int
+	color int
+}
+
+type borderDefs struct {
+	left, right, top, bottom borderDef
+}
+
+// sideDef is border of the named side (BorderSideLeft, etc.)
+type sideDef struct {
+	side string
+	def  borderDef
+}
+
+type cellDef struct {
+	cellX            int
+	verticalMerged   string
+	horizontalMerged string
+	textFlow         string
+	noWrap           bool
+	fitText          bool
+	diagonalDown     borderDef
+	diagonalUp       borderDef
+	vTextAlign       string
+	backgroundColor  int
+	paddings
+	borderDefs
+}
+
+type rowDef struct {
+	align        string
+	isHeader     bool
+	keepTogether bool
+	keepWithNext bool
+	height       int
+	insideH      borderDef
+	insideV      borderDef
+	indent       int
+	gap          int
+	width        int
+	isFloating   bool
+	positionX    int
+	positionY    int
+	fromX        string
+	fromY        string
+	noOverlap    bool
+	textDistance margins
+	paddings
+	borderDefs
+}
+
+type pictureDef struct {
+	format  string
+	data    bytes.Buffer
+	scaleX  int
+	scaleY  int
+	cropL   int
+	cropR   int
+	cropT   int
+	cropB   int
+	width   int
+	height  int
+	wGoal   int
+	hGoal   int
+	invalid bool
+	binary  bool // data is binary, not hex encoded
+}
+
+type parser struct {
+	tz    *tokenizer
+	doc   *Document
+	st    groupState
+	stack []groupState
+	done  bool
+
+	codePage    int
+	defaultFont int
+	ucSkip      int  // fallback characters still to be skipped
+	surrogate   rune // pending high surrogate of \uN pair
+
+	// font and color tables
+	fonts        map[int]int // rtf font number -> index in FontTable
+	fontCharsets map[int]int
+	fontEntry    font
+	fontNumber   int
+	fontName     strings.Builder
+	colors       []int // rtf color number -> color code
+	red          int
+	green        int
+	blue         int
+	colorSet     bool
+
+	contentState
+	saved     []contentState // states of containers interrupted by nested destinations
+	section   *Section       // section being parsed, nil for the first section of the document
+	pageStart int            // page number the section starts from on restart
+	markSize  int            // font size of the last footnote reference mark
+	colNo     int            // number of the column being defined by \colno
+	colWidths []int          // column widths collected from \colw of the section
+
+	pic *pictureDef
+}
+
+// contentState holds paragraph and table being parsed and the container they belong to
+type contentState struct {
+	content  *[]documentItem
+	maxWidth int
+	note     *Footnote // footnote or endnote being parsed
+
+	// paragraph
+	par       parState
+	cur       *Paragraph
+	text      []rune
+	textChars charState
+
+	// tables
+	row      rowDef
+	cells    []cellDef
+	cell     cellDef
+	border   *borderDef
+	cellPars []*Paragraph
+	rowCells [][]*Paragraph
+	table    *Table
+	space    *Paragraph // empty paragraph, which may be space before the following table
+}
+
+func newParser(r io.Reader) *parser {
+	p := &parser{
+		tz:       newTokenizer(r),
+		doc:      NewDocument(),
+		codePage: 1252,
+	}
+	p.st = groupState{uc: 1, chars: charState{fontSize: defaultFontSize}}
+	p.content = &p.doc.content
+	p.maxWidth = p.doc.maxWidth
+	p.resetPar()
+	return p
+}
+
+func (p *parser) parse() error {
+	tok, err := p.tz.next()
+	if err != nil || tok.kind != tokenGroupStart {
+		return errors.New("not an rtf document")
+	}
+	tok, err = p.tz.next()
+	if err != nil || tok.kind != tokenControlWord || tok.word != "rtf" {
+		return errors.New("not an rtf document")
+	}
+	for !p.done {
+		tok, err = p.tz.next()
+		if err == io.EOF {
+			return errors.New("unexpected end of rtf document")
+		}
+		if err != nil {
+			return err
+		}
+		if err = p.handle(tok); err != nil {
+			return err
+		}
+	}
+	p.applyColumnWidths()
+	p.setPageFormat()
+	p.endContent()
+	return nil
+}
+
+// endContent completes content, which is not finished with \par or \row
+func (p *parser) endContent() {
+	if p.hasParagraph() {
+		p.endParagraph()
+	}
+	if len(p.rowCells) > 0 || len(p.cellPars) > 0 {
+		p.endCell()
+		p.endRow()
+	}
+	p.flushSpace()
+}
+
+// startContent saves state of the current container and directs parsed content of the group to the new one.
+// Previous container is restored at the end of the group.
+func (p *parser) startContent(content *[]documentItem, maxWidth int) {
+	p.saved = append(p.saved, p.contentState)
+	p.contentState = contentState{content: content, maxWidth: maxWidth}
+	p.resetPar()
+	p.st.endContent = p.restoreContent
+}
+
+func (p *parser) restoreContent() {
+	p.endContent()
+	p.contentState = p.saved[len(p.saved)-1]
+	p.saved = p.saved[:len(p.saved)-1]
+}
+
+func (p *parser) handle(tok token) error {
+	switch tok.kind {
+	case tokenGroupStart:
+		p.stack = append(p.stack, p.st)
+		p.st.ignorable = false
+		p.st.wordsCount = 0
+		p.st.endContent = nil
+	case tokenGroupEnd:
+		p.endGroup()
+	case tokenControlWord:
+		if tok.word == "bin" {
+			if p.st.dest == destPicture {
+				p.pic.binary = true
+				return p.tz.copyBinary(&p.pic.data, tok.param)
+			}
+			return p.tz.copyBinary(io.Discard, tok.param)
+		}
+		p.st.wordsCount++
+		if p.st.dest == destSkip {
+			return nil
+		}
+		if p.st.ignorable && p.st.wordsCount == 1 && tok.word != "shppict" && tok.word != "fldinst" {
+			p.st.dest = destSkip
+			return nil
+		}
+		p.handleWord(tok)
+	case tokenControlSymbol:
+		if p.st.dest == destSkip {
+			return nil
+		}
+		p.handleSymbol(tok)
+	case tokenText:
+		p.handleText(tok.data)
+	}
+	return nil
+}
+
+func (p *parser) endGroup() {
+	if len(p.stack) == 0 {
+		p.done = true
+		return
+	}
+	closed := p.st
+	p.st = p.stack[len(p.stack)-1]
+	p.stack = p.stack[:len(p.stack)-1]
+	if closed.endContent != nil {
+		closed.endContent()
+	}
+	if closed.field != nil && closed.field != p.st.field {
+		p.endField(closed.field)
+	}
+	switch {
+	case closed.dest == destPicture && p.st.dest != destPicture:
+		p.endPicture()
+	case closed.dest == destFontTable && p.st.dest == destFontTable:
+		p.endFont()
+	}
+}
+
+func (p *parser) handleSymbol(tok token) {
+	switch tok.word {
+	case "*":
+		p.st.ignorable = true
+	case "'":
+		switch p.st.dest {
+		case destBody, destFieldInstruction:
+			if p.ucSkip > 0 {
+				p.ucSkip--
+				return
+			}
+			p.addRune(decodeByte(tok.data[0], p.textCodePage()))
+		case destFontTable:
+			p.fontName.WriteRune(decodeByte(tok.data[0], p.codePage))
+		}
+	default:
+		if r, ok := symbolChars[tok.word]; ok && (p.st.dest == destBody || p.st.dest == destFieldInstruction) {
+			p.addRune(r)
+		}
+	}
+}
+
+func (p *parser) handleText(data []byte) {
+	switch p.st.dest {
+	case destBody, destFieldInstruction:
+		for _, b := range data {
+			if p.ucSkip > 0 {
+				p.ucSkip--
+				continue
+			}
+			p.addRune(decodeByte(b, p.textCodePage()))
+		}
+	case destFontTable:
+		for _, b := range data {
+			if b == ';' {
+				p.endFont()
+				continue
+			}
+			p.fontName.WriteRune(decodeByte(b, p.codePage))
+		}
+	case destColorTable:
+		for _, b := range data {
+			if b == ';' {
+				p.endColor()
+			}
+		}
+	case destPicture:
+		p.pic.data.Write(data)
+	}
+}
+
+func (p *parser) handleWord(tok token) {
+	if skippedDestinations[tok.word] {
+		p.st.dest = destSkip
+		return
+	}
+	switch p.st.dest {
+	case destFontTable:
+		p.handleFontWord(tok)
+		return
+	case destColorTable:
+		p.handleColorWord(tok)
+		return
+	case destPicture:
+		p.handlePictureWord(tok)
+		return
+	case destFieldInstruction:
+		// Instruction text is kept, its formatting is taken from the field result
+		switch tok.word {
+		case "uc":
+			p.st.uc = tok.param
+		case "u":
+			p.addUnicode(tok.param)
+		}
+		return
+	}
+	if p.handleDocumentWord(tok) || p.handleSectionWord(tok) || p.handleNoteWord(tok) || p.handleCharWord(tok) || p.handleParWord(tok) || p.handleTableWord(tok) {
+		return
+	}
+	if r, ok := symbolWords[tok.word]; ok {
+		p.addRune(r)
+	}
+}
+
+// handleDocumentWord processes document and destination control words
+func (p *parser) handleDocumentWord(tok token) bool {
+	doc := p.doc
+	switch tok.word {
+	case "field":
+		p.flushText()
+		par := p.paragraph()
+		p.st.field = &fieldState{par: par, start: len(par.content)}
+	case "fldinst":
+		if p.st.field == nil {
+			p.st.dest = destSkip
+			break
+		}
+		p.st.dest = destFieldInstruction
+	case "fonttbl":
+		p.st.dest = destFontTable
+		p.fonts = map[int]int{}
+		p.fontCharsets = map[int]int{}
+		doc.NewFontTable()
+		p.startFont(0)
+	case "colortbl":
+		p.st.dest = destColorTable
+		p.colors = nil
+		doc.NewColorTable()
+		p.resetColor()
+	case "header", "headerl", "headerr", "headerf", "footer", "footerl", "footerr", "footerf":
+		isFooter, kind := tok.word[0] == 'f', tok.word[6:]
+		var hf *HeaderFooter
+		switch {
+		case p.section == nil:
+			hf = doc.addHeaderFooter(isFooter, kind)
+		case isFooter:
+			hf = p.section.AddFooter(kind)
+		default:
+			hf = p.section.AddHeader(kind)
+		}
+		p.startContent(&hf.content, hf.maxWidth)
+	case "shppict":
+		p.st.dest = destPictureGroup
+	case "pict":
+		p.st.dest = destPicture
+		p.pic = &pictureDef{scaleX: 100, scaleY: 100}
+	case "ansicpg":
+		p.codePage = tok.param
+	case "deff":
+		p.defaultFont = tok.param
+		p.st.chars.font = tok.param
+	case "uc":
+		p.st.uc = tok.param
+	case "u":
+		p.addUnicode(tok.param)
+	case "paperw":
+		doc.pagesize.width = tok.param
+		p.updateMaxWidth()
+	case "paperh":
+		doc.pagesize.height = tok.param
+	case "margl":
+		doc.marginLeft = tok.param
+		p.updateMaxWidth()
+	case "margr":
+		doc.marginRight = tok.param
+		p.updateMaxWidth()
+	case "margt":
+		doc.marginTop = tok.param
+	case "margb":
+		doc.marginBottom = tok.param
+	case "landscape":
+		doc.orientation = OrientationLandscape
+	default:
+		return false
+	}
+	return true
+}
+
+// handleNoteWord processes footnotes, endnotes and their settings
+func (p *parser) handleNoteWord(tok token) bool {
+	doc := p.doc
+	switch tok.word {
+	case "chftn":
+		// Mark inside the note repeats the reference mark
+		if p.note == nil {
+			p.markSize = p.st.chars.fontSize
+		}
+	case "footnote":
+		p.startNote()
+	case "ftnalt":
+		if p.note != nil {
+			p.note.isEndnote = true
+		}
+	case "ftnrstcont", "ftnrestart", "ftnrstpg":
+		doc.SetFootnoteRestart(tok.word[3:])
+	case "aftnrstcont", "aftnrestart":
+		doc.SetEndnoteRestart(tok.word[4:])
+	case "aenddoc", "aendnotes":
+		doc.SetEndnotePlacement(tok.word)
+	default:
+		switch {
+		case strings.HasPrefix(tok.word, "ftnn"):
+			doc.SetFootnoteNumbering(findNoteFormat(tok.word[4:]))
+		case strings.HasPrefix(tok.word, "aftnn"):
+			doc.SetEndnoteNumbering(findNoteFormat(tok.word[5:]))
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// startNote adds note to the current paragraph and directs content of the group to it
+func (p *parser) startNote() {
+	p.flushText()
+	fn := p.paragraph().addNote(false)
+	if p.markSize > 0 {
+		fn.markFontSize = p.markSize / 2
+	}
+	p.markSize = 0
+	var items []documentItem
+	p.startContent(&items, fn.maxWidth)
+	p.note = fn
+	p.st.endContent = func() {
+		p.restoreContent()
+		// Notes consist of paragraphs only
+		for _, c := range items {
+			if par, ok := c.(*Paragraph); ok {
+				fn.content = append(fn.content, par)
+			}
+		}
+	}
+}
+
+// findNoteFormat returns numbering format of the rtf note format suffix
+func findNoteFormat(suffix string) string {
+	for _, format := range []string{
+		ListFormatDecimal,
+		ListFormatLowerLetter,
+		ListFormatUpperLetter,
+		ListFormatLowerRoman,
+		ListFormatUpperRoman,
+		ListFormatBullet,
+	} {
+		if getNoteFormat(format) == suffix {
+			return format
+		}
+	}
+	return ""
+}
+
+// handleCharWord processes character formatting control words
+func (p *parser) handleCharWord(tok token) bool {
+	ch := &p.st.chars
+	on := !tok.hasParam || tok.param != 0
+	switch tok.word {
+	case "plain":
+		*ch = charState{fontSize: defaultFontSize, font: p.defaultFont}
+	case "b":
+		ch.bold = on
+	case "i":
+		ch.italic = on
+	case "ul":
+		ch.underline = on
+	case "ulnone":
+		ch.underline = false
+	case "strike":
+		ch.strike = on
+	case "scaps":
+		ch.scaps = on
+	case "super":
+		ch.super, ch.sub = true, false
+	case "sub":
+		ch.super, ch.sub = false, true
+	case "nosupersub":
+		ch.super, ch.sub = false, false
+	case "horzvert":
+		ch.rotated = true
+	case "fs":
+		ch.fontSize = tok.param
+	case "f":
+		ch.font = tok.param
+	case "cf":
+		ch.color = tok.param
+	default:
+		return false
+	}
+	return true
+}
+
+// handleParWord processes paragraph formatting control words
+func (p *parser) handleParWord(tok token) bool {
+	switch tok.word {
+	case "pard":
+		p.resetPar()
+	case "par":
+		p.endParagraph()
+	case "nestcell":
+		// Nested tables are flattened, their cells become paragraphs of the outer cell
+		p.endParagraph()
+	case "line":
+		p.flushText()
+		p.paragraph().AddNewLine()
+	case "tab":
+		p.flushText()
+		p.paragraph().AddTab()
+	case "page":
+		p.flushText()
+		p.paragraph().AddPageBreak()
+	case "column":
+		p.flushText()
+		p.paragraph().AddColumnBreak()
+	case "ql":
+		p.par.align = AlignLeft
+	case "qc":
+		p.par.align = AlignCenter
+	case "qr":
+		p.par.align = AlignRight
+	case "qj":
+		p.par.align = AlignJustify
+	case "qd":
+		p.par.align = AlignDistribute
+	case "fi":
+		p.par.indentFirstLine = tok.param
+	case "li":
+		p.par.indentLeftIndent = tok.param
+	case "ri":
+		p.par.indentRightIndent = tok.param
+	case "intbl":
+		p.par.inTable = true
+	case "sb":
+		p.par.spaceBefore = tok.param
+	case "sa":
+		p.par.spaceAfter = tok.param
+	case "sbauto":
+		p.par.autoSpaceBefore = tok.param != 0
+	case "saauto":
+		p.par.autoSpaceAfter = tok.param != 0
+	case "sl":
+		p.par.lineSpacing = tok.param
+	case "slmult":
+		p.par.lineMultiple = tok.param != 0
+	case "keepn":
+		p.par.keepWithNext = true
+	case "keep":
+		p.par.keepTogether = true
+	case "widctlpar", "nowidctlpar":
+		p.par.widowControl = tok.word
+	case "pagebb":
+		p.par.pageBreakBefore = true
+	case "brdrl":
+		p.setBorder(&p.par.left)
+	case "brdrr":
+		p.setBorder(&p.par.right)
+	case "brdrt":
+		p.setBorder(&p.par.top)
+	case "brdrb":
+		p.setBorder(&p.par.bottom)
+	case "box":
+		p.setBorder(&p.par.left)
+		p.par.right, p.par.top, p.par.bottom = p.par.left, p.par.left, p.par.left
+		// Style of the box is set to all sides at the paragraph end
+		p.par.isBox = true
+	case "tqc", "tqr", "tqdec":
+		p.par.tab.align = tok.word[2:]
+	case "tldot", "tlmdot", "tlhyph", "tlul", "tlth", "tleq":
+		p.par.tab.leader = tok.word[2:]
+	case "tx":
+		p.par.tab.position = tok.param
+		if p.par.tab.align == "" {
+			p.par.tab.align = AlignLeft
+		}
+		p.par.tabStops = append(p.par.tabStops, p.par.tab)
+		p.par.tab = tabStop{}
+	case "brsp":
+		p.par.borderSpace = tok.param
+	case "cbpat":
+		p.par.backgroundColor = tok.param
+	case "shading":
+		p.par.shading = tok.param
+	case "cfpat":
+		p.par.shadingColor = tok.param
+	default:
+		return false
+	}
+	return true
+}
+
+// handleTableWord processes table row and cell control words
+func (p *parser) handleTableWord(tok token) bool {
+	switch tok.word {
+	case "trowd":
+		p.row = rowDef{}
+		p.cells = nil
+		p.cell = cellDef{}
+		p.border = nil
+	case "trhdr":
+		p.row.isHeader = true
+	case "trkeep":
+		p.row.keepTogether = true
+	case "trkeepfollow":
+		p.row.keepWithNext = true
+	case "trrh":
+		p.row.height = tok.param
+	case "trql":
+		p.row.align = AlignLeft
+	case "trqc":
+		p.row.align = AlignCenter
+	case "trqr":
+		p.row.align = AlignRight
+	case "trpaddl":
+		p.row.paddingLeft = tok.param
+	case "trpaddr":
+		p.row.paddingRight = tok.param
+	case "trpaddt":
+		p.row.paddingTop = tok.param
+	case "trpaddb":
+		p.row.paddingBottom = tok.param
+	case "trbrdrl":
+		p.setBorder(&p.row.left)
+	case "trbrdrr":
+		p.setBorder(&p.row.right)
+	case "trbrdrt":
+		p.setBorder(&p.row.top)
+	case "trbrdrb":
+		p.setBorder(&p.row.bottom)
+	case "trleft":
+		p.row.indent = tok.param
+	case "trgaph":
+		p.row.gap = tok.param
+	case "trwWidth":
+		p.row.width = tok.param
+	case "tposx", "tposy":
+		p.row.isFloating = true
+		if tok.word == "tposx" {
+			p.row.positionX = tok.param
+		} else {
+			p.row.positionY = tok.param
+		}
+	case "tphpg", "tphmrg", "tphcol":
+		p.row.fromX = tok.word[3:]
+	case "tpvpg", "tpvmrg", "tpvpara":
+		p.row.fromY = tok.word[3:]
+	case "tabsnoovrlp":
+		p.row.noOverlap = true
+	case "tdfrmtxtLeft":
+		p.row.textDistance.marginLeft = tok.param
+	case "tdfrmtxtRight":
+		p.row.textDistance.marginRight = tok.param
+	case "tdfrmtxtTop":
+		p.row.textDistance.marginTop = tok.param
+	case "tdfrmtxtBottom":
+		p.row.textDistance.marginBottom = tok.param
+	case "trbrdrh":
+		p.setBorder(&p.row.insideH)
+	case "trbrdrv":
+		p.setBorder(&p.row.insideV)
+	case "cldglu":
+		p.setBorder(&p.cell.diagonalDown)
+	case "cldgll":
+		p.setBorder(&p.cell.diagonalUp)
+	case "clbrdrl":
+		p.setBorder(&p.cell.left)
+	case "clbrdrr":
+		p.setBorder(&p.cell.right)
+	case "clbrdrt":
+		p.setBorder(&p.cell.top)
+	case "clbrdrb":
+		p.setBorder(&p.cell.bottom)
+	case "brdrw":
+		if p.border != nil {
+			p.border.width = tok.param
+		}
+	case "brdrcf":
+		if p.border != nil {
+			p.border.color = tok.param
+		}
+	case "brdrnone", "brdrnil":
+		if p.border != nil {
+			p.border.on = false
+		}
+	case "clpadl":
+		p.cell.paddingLeft = tok.param
+	case "clpadr":
+		p.cell.paddingRight = tok.param
+	case "clpadt":
+		p.cell.paddingTop = tok.param
+	case "clpadb":
+		p.cell.paddingBottom = tok.param
+	case "clmgf":
+		p.cell.horizontalMerged = "gf"
+	case "clmrg":
+		p.cell.horizontalMerged = "rg"
+	case "clvmgf":
+		p.cell.verticalMerged = "gf"
+	case "clvmrg":
+		p.cell.verticalMerged = "rg"
+	case "clvertalt":
+		p.cell.vTextAlign = VAlignTop
+	case "clvertalc":
+		p.cell.vTextAlign = VAlignMiddle
+	case "clvertalb":
+		p.cell.vTextAlign = VAlignBottom
+	case "cltxlrtb", "cltxtbrl", "cltxbtlr", "cltxlrtbv", "cltxtbrlv":
+		p.cell.textFlow = tok.word[4:]
+	case "clNoWrap":
+		p.cell.noWrap = true
+	case "clFitText":
+		p.cell.fitText = true
+	case "clcbpat":
+		p.cell.backgroundColor = tok.param
+	case "cellx":
+		p.cell.cellX = tok.param
+		p.cells = append(p.cells, p.cell)
+		p.cell = cellDef{}
+		p.border = nil
+	case "cell":
+		p.endCell()
+	case "row":
+		p.endRow()
+	default:
+		if p.border != nil && strings.HasPrefix(tok.word, "brdr") && isBorderStyle(tok.word[4:]) {
+			p.border.style = tok.word[4:]
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// updateMaxWidth updates width of the document or section content after page setup changes
+func (p *parser) updateMaxWidth() {
+	p.doc.updateMaxWidth()
+	width := p.doc.maxWidth
+	if p.section != nil {
+		p.section.updateMaxWidth()
+		width = p.section.maxWidth
+	}
+	if len(p.saved) == 0 {
+		p.maxWidth = width
+	}
+}
+
+// setPageFormat looks for the known page formats of the parsed paper sizes
+func (p *parser) setPageFormat() {
+	doc := p.doc
+	doc.pageFormat = findPageFormat(doc.pagesize, doc.orientation)
+	doc.updateMaxWidth()
+	for _, s := range doc.sections {
+		s.pageFormat = findPageFormat(s.pagesize, s.orientation)
+		s.updateMaxWidth()
+	}
+}
+
+func findPageFormat(pagesize size, orientation string) string {
+	res := ""
+	for _, format := range []string{FormatLetter, FormatA5, FormatA4, FormatA3, FormatA2} {
+		if s, err := getSize(format, orientation); err == nil && s == pagesize {
+			res = format
+		}
+	}
+	return res
+}
+
+// pageSettings points to page settings of the document or of the section being parsed
+type pageSettings struct {
+	orientation *string
+	margins     *margins
+	pagesize    *size
+	columns     *columnLayout
+}
+
+func (p *parser) page() pageSettings {
+	if s := p.section; s != nil {
+		return pageSettings{orientation: &s.orientation, margins: &s.margins, pagesize: &s.pagesize, columns: &s.columnLayout}
+	}
+	doc := p.doc
+	return pageSettings{orientation: &doc.orientation, margins: &doc.margins, pagesize: &doc.pagesize, columns: &doc.columnLayout}
+}
+
+// applyColumnWidths sets collected column widths to the current section
+func (p *parser) applyColumnWidths() {
+	if len(p.colWidths) == 0 {
+		return
+	}
+	p.page().columns.setColumnWidths(p.colWidths)
+	p.colWidths, p.colNo = nil, 0
+	p.updateMaxWidth()
+}
+
+// startSection completes content of the previous section and directs following content to the new one
+func (p *parser) startSection() {
+	if len(p.saved) > 0 {
+		return
+	}
+	p.endContent()
+	p.applyColumnWidths()
+	s := p.doc.AddSection()
+	p.section = s
+	p.pageStart = 1
+	p.contentState = contentState{content: &s.content, maxWidth: s.maxWidth}
+	p.resetPar()
+}
+
+// handleSectionWord processes section properties
+func (p *parser) handleSectionWord(tok token) bool {
+	pg := p.page()
+	s := p.section
+	switch tok.word {
+	case "sect":
+		p.startSection()
+	case "sectd":
+		if s != nil {
+			// Page size and margins are inherited from the document
+			s.orientation = OrientationPortrait
+			s.pageNumberStart = 0
+			s.columnLayout = columnLayout{columnSpacing: defaultColumnSpacing}
+			p.colWidths, p.colNo = nil, 0
+			p.pageStart = 1
+			p.updateMaxWidth()
+		}
+	case "lndscpsxn":
+		*pg.orientation = OrientationLandscape
+	case "pgwsxn":
+		pg.pagesize.width = tok.param
+		p.updateMaxWidth()
+	case "pghsxn":
+		pg.pagesize.height = tok.param
+	case "marglsxn":
+		pg.margins.marginLeft = tok.param
+		p.updateMaxWidth()
+	case "margrsxn":
+		pg.margins.marginRight = tok.param
+		p.updateMaxWidth()
+	case "margtsxn":
+		pg.margins.marginTop = tok.param
+	case "margbsxn":
+		pg.margins.marginBottom = tok.param
+	case "cols":
+		pg.columns.setColumns(tok.param)
+		p.updateMaxWidth()
+	case "colsx":
+		pg.columns.setColumnSpacing(tok.param)
+		p.updateMaxWidth()
+	case "linebetcol":
+		pg.columns.lineBetween = true
+	case "colno":
+		p.colNo = tok.param
+	case "colw":
+		i := p.colNo - 1
+		if i < 0 {
+			i = len(p.colWidths)
+		}
+		for len(p.colWidths) <= i {
+			p.colWidths = append(p.colWidths, 0)
+		}
+		p.colWidths[i] = tok.param
+	case "pgnrestart":
+		if s != nil {
+			s.pageNumberStart = p.pageStart
+		}
+	case "pgnstarts":
+		p.pageStart = tok.param
+		if s != nil && s.pageNumberStart > 0 {
+			s.SetPageNumberRestart(tok.param)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *parser) handleFontWord(tok token) {
+	switch tok.word {
+	case "f":
+		p.startFont(tok.param)
+	case "fcharset":
+		p.fontEntry.charset = tok.param
+	case "fprq":
+		p.fontEntry.prq = tok.param
+	case "fnil", "froman", "fswiss", "fmodern", "fscript", "fdecor", "ftech", "fbidi":
+		p.fontEntry.family = tok.word[1:]
+	}
+}
+
+func (p *parser) startFont(number int) {
+	p.fontNumber = number
+	p.fontEntry = font{}
+	p.fontName.Reset()
+}
+
+func (p *parser) endFont() {
+	name := strings.TrimSpace(p.fontName.String())
+	p.fontName.Reset()
+	if name == "" {
+		return
+	}
+	code := name
+	for _, f := range defaultFontTable {
+		if f.name == name {
+			code = f.code
+		}
+	}
+	ft := p.doc.fontColor
+	ft.AddFont(p.fontEntry.family, p.fontEntry.charset, p.fontEntry.prq, name, code)
+	p.fonts[p.fontNumber] = len(*ft) - 1
+	p.fontCharsets[p.fontNumber] = p.fontEntry.charset
+}
+
+func (p *parser) handleColorWord(tok token) {
+	switch tok.word {
+	case "red":
+		p.red, p.colorSet = tok.param, true
+	case "green":
+		p.green, p.colorSet = tok.param, true
+	case "blue":
+		p.blue, p.colorSet = tok.param, true
+	}
+}
+
+func (p *parser) resetColor() {
+	p.red, p.green, p.blue, p.colorSet = 0, 0, 0, false
+}
+
+func (p *parser) endColor() {
+	defer p.resetColor()
+	if !p.colorSet {
+		// Auto color
+		p.colors = append(p.colors, 0)
+		return
+	}
+	c := color.RGBA{R: uint8(p.red), G: uint8(p.green), B: uint8(p.blue), A: 255}
+	name := fmt.Sprintf("color_%02x%02x%02x", c.R, c.G, c.B)
+	for _, dc := range defaultColorTable {
+		if dc.rgbColor == c {
+			name = dc.name
+		}
+	}
+	ct := p.doc.colorTable
+	ct.AddColor(c, name)
+	p.colors = append(p.colors, len(*ct))
+}
+
+// colorCode returns color code (position in ColorTable starting from 1) of rtf color number
+func (p *parser) colorCode(number int) int {
+	if p.colors == nil {
+		return number
+	}
+	if number < 0 || number >= len(p.colors) {
+		return 0
+	}
+	return p.colors[number]
+}
+
+// colorName returns name of the color for rtf color number
+func (p *parser) colorName(number int) string {
+	code := p.colorCode(number)
+	ct := *p.doc.colorTable
+	if code < 1 || code > len(ct) {
+		return ""
+	}
+	return ct[code-1].name
+}
+
+// fontCode returns index in FontTable of rtf font number
+func (p *parser) fontCode(number int) int {
+	if p.fonts == nil {
+		return number
+	}
+	return p.fonts[number]
+}
+
+func (p *parser) textCodePage() int {
+	if p.fontCharsets[p.st.chars.font] == fontCharsetCyrillic {
+		return 1251
+	}
+	return p.codePage
+}
+
+func (p *parser) handlePictureWord(tok token) {
+	pic := p.pic
+	switch tok.word {
+	case "pngblip":
+		pic.format = ImageFormatPng
+	case "jpegblip":
+		pic.format = ImageFormatJpeg
+	case "emfblip", "wmetafile", "macpict", "dibitmap", "wbitmap", "pmmetafile":
+		pic.invalid = true
+	case "picscalex":
+		pic.scaleX = tok.param
+	case "picscaley":
+		pic.scaleY = tok.param
+	case "piccropl":
+		pic.cropL = tok.param
+	case "piccropr":
+		pic.cropR = tok.param
+	case "piccropt":
+		pic.cropT = tok.param
+	case "piccropb":
+		pic.cropB = tok.param
+	case "picw":
+		pic.width = tok.param
+	case "pich":
+		pic.height = tok.param
+	case "picwgoal":
+		pic.wGoal = tok.param
+	case "pichgoal":
+		pic.hGoal = tok.param
+	}
+}
+
+func (p *parser) endPicture() {
+	def := p.pic
+	p.pic = nil
+	if def == nil || def.invalid || def.format == "" {
+		return
+	}
+	src := def.data.Bytes()
+	if !def.binary {
+		src = make([]byte, hex.DecodedLen(def.data.Len()))
+		n, err := hex.Decode(src, bytes.Join(bytes.Fields(def.data.Bytes()), nil))
+		if err != nil {
+			return
+		}
+		src = src[:n]
+	}
+	p.flushText()
+	pic := p.paragraph().AddPicture(src, def.format)
+	pic.scaleX, pic.scaleY = def.scaleX, def.scaleY
+	pic.cropL, pic.cropR, pic.cropT, pic.cropB = def.cropL, def.cropR, def.cropT, def.cropB
+	switch {
+	case def.wGoal > 0 && def.hGoal > 0:
+		pic.width, pic.height = getPixelsFromTwips(def.wGoal), getPixelsFromTwips(def.hGoal)
+	case def.width > 0 && def.height > 0:
+		pic.width, pic.height = def.width, def.height
+	}
+}
+
+func (p *parser) addUnicode(value int) {
+	if value < 0 {
+		value += kUnsigned16BitValueIntoSigned16BitValueRange
+	}
+	r := rune(value)
+	p.ucSkip = p.st.uc
+	switch {
+	case r >= kUTF16HighSurrogateStart && r < kUTF16LowSurrogateStart:
+		p.surrogate = r
+		return
+	case r >= kUTF16LowSurrogateStart && r <= 0xDFFF:
+		if p.surrogate == 0 {
+			return
+		}
+		r = kStartOfUnicodePlane1 + (p.surrogate-kUTF16HighSurrogateStart)*kHigh10BitsShiftToLow10Bits + (r - kUTF16LowSurrogateStart)
+		p.surrogate = 0
+	}
+	p.addRune(r)
+}
+
+func (p *parser) addRune(r rune) {
+	if p.st.dest == destFieldInstruction {
+		p.st.field.instruction = append(p.st.field.instruction, r)
+		return
+	}
+	if p.st.dest != destBody {
+		return
+	}
+	if len(p.text) > 0 && p.textChars != p.st.chars {
+		p.flushText()
+	}
+	p.textChars = p.st.chars
+	p.text = append(p.text, r)
+}
+
+// endField replaces text of the field result with Field. Result of unsupported field is kept as text.
+func (p *parser) endField(fs *fieldState) {
+	if p.st.dest != destBody || p.cur != fs.par {
+		return
+	}
+	p.flushText()
+	f := parseFieldInstruction(string(fs.instruction))
+	if f == nil {
+		return
+	}
+	par := p.cur
+	var content strings.Builder
+	for i, item := range par.content[fs.start:] {
+		txt, ok := item.(*Text)
+		if !ok {
+			// Result with other content is kept as is
+			return
+		}
+		if i == 0 {
+			f.Text = *txt
+		}
+		content.WriteString(txt.content)
+	}
+	if len(par.content) == fs.start {
+		f.Text = Text{fontSize: defaultFontSize / 2, generalSettings: par.generalSettings}
+	}
+	f.content = content.String()
+	par.content = append(par.content[:fs.start], f)
+}
+
+// parseFieldInstruction returns Field of the instruction or nil for unsupported field type
+func parseFieldInstruction(instruction string) *Field {
+	args := splitFieldInstruction(instruction)
+	if len(args) == 0 {
+		return nil
+	}
+	f := Field{fieldType: strings.ToUpper(args[0])}
+	switch f.fieldType {
+	case FieldPage, FieldNumPages, FieldSectionPages, FieldDate, FieldTime, FieldHyperlink:
+	default:
+		return nil
+	}
+	for i := 1; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "\\l" && f.fieldType == FieldHyperlink:
+			f.switches = arg
+		case (arg == "\\@" || arg == "\\*") && i+1 < len(args):
+			i++
+			if !strings.EqualFold(args[i], "MERGEFORMAT") {
+				f.format = args[i]
+			}
+		case f.argument == "" && !strings.HasPrefix(arg, "\\"):
+			f.argument = arg
+		}
+	}
+	return &f
+}
+
+// splitFieldInstruction splits field instruction into words, quoted arguments are unescaped
+func splitFieldInstruction(instruction string) []string {
+	var res []string
+	rs := []rune(instruction)
+	for i := 0; i < len(rs); i++ {
+		if rs[i] == ' ' {
+			continue
+		}
+		var arg strings.Builder
+		if rs[i] == '"' {
+			for i++; i < len(rs) && rs[i] != '"'; i++ {
+				if rs[i] == '\\' && i+1 < len(rs) {
+					i++
+				}
+				arg.WriteRune(rs[i])
+			}
+		} else {
+			for ; i < len(rs) && rs[i] != ' '; i++ {
+				arg.WriteRune(rs[i])
+			}
+		}
+		res = append(res, arg.String())
+	}
+	return res
+}
+
+// flushText adds collected text as Text instance to current paragraph
+func (p *parser) flushText() {
+	if len(p.text) == 0 {
+		return
+	}
+	ch := p.textChars
+	par := p.paragraph()
+	txt := Text{
+		fontSize:      ch.fontSize / 2,
+		fontCode:      p.fontCode(ch.font),
+		colorCode:     p.colorCode(ch.color),
+		isBold:        ch.bold,
+		isItalic:      ch.italic,
+		isUnderlining: ch.underline,
+		isScaps:       ch.scaps,
+		isSuper:       ch.super,
+		isSub:         ch.sub,
+		isStrike:      ch.strike,
+		rotated:       ch.rotated,
+		content:       string(p.text),
+		generalSettings: generalSettings{
+			colorTable: par.colorTable,
+			fontColor:  par.fontColor,
+			styleSheet: par.styleSheet,
+			listTable:  par.listTable,
+		},
+	}
+	par.content = append(par.content, &txt)
+	p.text = p.text[:0]
+}
+
+func (p *parser) resetPar() {
+	p.par = parState{align: AlignLeft}
+}
+
+// paragraph returns current paragraph, which is created on the first content
+func (p *parser) paragraph() *Paragraph {
+	if p.cur == nil {
+		// Section properties are completed by its content
+		p.applyColumnWidths()
+		p.cur = &Paragraph{
+			generalSettings: generalSettings{
+				colorTable: p.doc.colorTable,
+				fontColor:  p.doc.fontColor,
+				styleSheet: p.doc.styleSheet,
+				listTable:  p.doc.listTable,
+			},
+			allowedWidth: p.maxWidth,
+		}
+		p.cur.updateMaxWidth()
+	}
+	return p.cur
+}
+
+// hasParagraph reports whether there is not completed paragraph content
+func (p *parser) hasParagraph() bool {
+	return p.cur != nil || len(p.text) > 0
+}
+
+// endParagraph completes current paragraph and puts it to the document or to the table cell
+func (p *parser) endParagraph() {
+	p.flushText()
+	par := p.paragraph()
+	p.cur = nil
+	par.align = p.par.align
+	par.paragraphSpacing = p.par.paragraphSpacing
+	par.tabStops = p.par.tabStops
+	if p.par.isBox {
+		p.par.right, p.par.top, p.par.bottom = p.par.left, p.par.left, p.par.left
+	}
+	for _, s := range p.par.sides() {
+		if s.def.on {
+			par.SetBorderSide(s.side, s.def.style, s.def.width, p.colorName(s.def.color))
+		}
+	}
+	par.SetBorderSpace(p.par.borderSpace)
+	if p.par.backgroundColor > 0 {
+		par.SetBackgroundColor(p.colorName(p.par.backgroundColor))
+	}
+	if p.par.shading > 0 {
+		par.SetShading(p.par.shading/100, p.colorName(p.par.shadingColor))
+	}
+	par.indentFirstLine = p.par.indentFirstLine
+	par.indentLeftIndent = p.par.indentLeftIndent
+	par.indentRightIndent = p.par.indentRightIndent
+	if p.par.inTable {
+		par.isTable = true
+		p.cellPars = append(p.cellPars, par)
+		return
+	}
+	if len(par.content) == 0 && p.par.isTableSpace() {
+		// Space written after the table is its bottom margin, other one may be top margin of the next table
+		if p.table != nil {
+			p.table.SetMarginBottom(-p.par.lineSpacing)
+			p.table = nil
+			return
+		}
+		p.flushSpace()
+		p.space = par
+		return
+	}
+	p.flushSpace()
+	*p.content = append(*p.content, par)
+	p.table = nil
+}
+
+// isTableSpace reports whether paragraph has only exact line spacing, as space before or after the table has
+func (ps parState) isTableSpace() bool {
+	if ps.lineSpacing >= 0 || ps.lineMultiple {
+		return false
+	}
+	space := parState{align: AlignLeft}
+	space.lineSpacing = ps.lineSpacing
+	return ps.align == space.align && ps.paragraphSpacing == space.paragraphSpacing &&
+		ps.borderDefs == space.borderDefs && !ps.isBox && ps.borderSpace == 0 &&
+		ps.indentFirstLine == 0 && ps.indentLeftIndent == 0 && ps.indentRightIndent == 0 &&
+		ps.backgroundColor == 0 && ps.shading == 0 && len(ps.tabStops) == 0
+}
+
+// flushSpace puts pending space paragraph, which isn't followed by the table, to the content
+func (p *parser) flushSpace() {
+	if p.space != nil {
+		*p.content = append(*p.content, p.space)
+		p.space = nil
+	}
+}
+
+func (p *parser) endCell() {
+	if p.hasParagraph() {
+		p.par.inTable = true
+		p.endParagraph()
+	}
+	p.rowCells = append(p.rowCells, p.cellPars)
+	p.cellPars = nil
+}
+
+func (p *parser) setBorder(b *borderDef) {
+	*b = borderDef{on: true, style: BorderSingleThickness}
+	p.border = b
+}
+
+// endRow adds row with collected cells to the current table
+func (p *parser) endRow() {
+	defer func() { p.rowCells = nil }()
+	if len(p.cells) == 0 && len(p.rowCells) == 0 {
+		return
+	}
+	if p.table == nil {
+		p.table = newTable(p.doc.generalSettings, p.maxWidth)
+		if p.space != nil {
+			p.table.SetMarginTop(-p.space.lineSpacing)
+			p.space = nil
+		}
+		*p.content = append(*p.content, p.table)
+		// Rows without aligning are left aligned
+		p.table.SetAlign(AlignLeft).SetAlign(p.row.align)
+		if p.row.indent != 0 {
+			p.table.SetMarginLeft(p.row.indent)
+		}
+		p.table.SetCellGap(p.row.gap).
+			SetWidth(p.row.width)
+		if p.row.isFloating {
+			fromX, fromY := PositionFromColumn, PositionFromParagraph
+			if p.row.fromX != "" {
+				fromX = p.row.fromX
+			}
+			if p.row.fromY != "" {
+				fromY = p.row.fromY
+			}
+			p.table.SetPosition(p.row.positionX, p.row.positionY, fromX, fromY).
+				SetOverlap(!p.row.noOverlap)
+			p.table.textDistance = p.row.textDistance
+		}
+		p.table.SetPaddingLeft(p.row.paddingLeft).
+			SetPaddingRight(p.row.paddingRight).
+			SetPaddingTop(p.row.paddingTop).
+			SetPaddingBottom(p.row.paddingBottom)
+		b := p.row.first()
+		p.table.SetBorderLeft(p.row.left.on).
+			SetBorderRight(p.row.right.on).
+			SetBorderTop(p.row.top.on).
+			SetBorderBottom(p.row.bottom.on).
+			SetBorderStyle(b.style).
+			SetBorderColor(p.colorName(b.color)).
+			SetBorderWidth(b.width)
+	}
+	t := p.table
+
+	tr := t.AddTableRow()
+	b := p.row.first()
+	tr.SetBorderLeft(p.row.left.on).
+		SetBorderRight(p.row.right.on).
+		SetBorderTop(p.row.top.on).
+		SetBorderBottom(p.row.bottom.on).
+		SetBorderStyle(b.style).
+		SetBorderColor(p.colorName(b.color)).
+		SetBorderWidth(b.width)
+	tr.SetHeader(p.row.isHeader).
+		SetKeepTogether(p.row.keepTogether).
+		SetKeepWithNext(p.row.keepWithNext)
+	tr.height = p.row.height
+	tr.indent = p.row.indent
+	rowSides := append(p.row.sides(), sideDef{BorderSideInsideH, p.row.insideH}, sideDef{BorderSideInsideV, p.row.insideV})
+	for _, s := range rowSides {
+		if s.def.on {
+			tr.SetBorderSide(s.side, s.def.style, s.def.width, p.colorName(s.def.color))
+		}
+	}
+
+	defs := p.cells
+	// Row without definitions gets equal cells
+	if len(defs) < len(p.rowCells) {
+		defs = append([]cellDef(nil), defs...)
+		for i := len(defs); i < len(p.rowCells); i++ {
+			defs = append(defs, cellDef{cellX: (i + 1) * p.maxWidth / len(p.rowCells)})
+		}
+	}
+	left, rowWidth := p.row.indent, 0
+	for i, def := range defs {
+		dc := tr.AddDataCell(def.cellX - left)
+		left = def.cellX
+		rowWidth += dc.cellWidth
+		b := def.first()
+		dc.SetBorderLeft(def.left.on).
+			SetBorderRight(def.right.on).
+			SetBorderTop(def.top.on).
+			SetBorderBottom(def.bottom.on)
+		if b.on {
+			dc.SetBorderStyle(b.style).
+				SetBorderColor(p.colorName(b.color)).
+				SetBorderWidth(b.width)
+		}
+		for _, s := range def.sides() {
+			if s.def.on {
+				dc.SetBorderSide(s.side, s.def.style, s.def.width, p.colorName(s.def.color))
+			}
+		}
+		if def.diagonalDown.on {
+			dc.SetDiagonalDown(def.diagonalDown.style, def.diagonalDown.width, p.colorName(def.diagonalDown.color))
+		}
+		if def.diagonalUp.on {
+			dc.SetDiagonalUp(def.diagonalUp.style, def.diagonalUp.width, p.colorName(def.diagonalUp.color))
+		}
+		dc.SetPaddingLeft(def.paddingLeft).
+			SetPaddingRight(def.paddingRight).
+			SetPaddingTop(def.paddingTop).
+			SetPaddingBottom(def.paddingBottom)
+		dc.verticalMerged = def.verticalMerged
+		dc.horizontalMerged = def.horizontalMerged
+		dc.vTextAlign = def.vTextAlign
+		dc.SetTextFlow(def.textFlow).
+			SetNoWrap(def.noWrap).
+			SetFitText(def.fitText)
+		if def.backgroundColor > 0 {
+			dc.SetBackgroundColor(p.colorName(def.backgroundColor))
+		}
+		if i < len(p.rowCells) {
+			for _, par := range p.rowCells[i] {
+				par.allowedWidth = dc.maxWidth
+				par.updateMaxWidth()
+			}
+			for _, par := range p.rowCells[i] {
+				dc.content = append(dc.content, par)
+			}
+		}
+	}
+	if rowWidth > t.width {
+		t.SetWidth(rowWidth)
+	}
+}
+
+// sides returns borders of the set in fixed order: left, right, top and bottom
+func (bd borderDefs) sides() []sideDef {
+	return []sideDef{{BorderSideLeft, bd.left}, {BorderSideRight, bd.right}, {BorderSideTop, bd.top}, {BorderSideBottom, bd.bottom}}
+}
+
+// first returns first visible border of the set
+func (bd borderDefs) first() borderDef {
+	for _, b := range []borderDef{bd.left, bd.right, bd.top, bd.bottom} {
+		if b.on {
+			return b
+		}
+	}
+	return borderDef{}
+}
@@ -0,0 +1,358 @@
+package rtfdoc
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenizer(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []token
+	}{
+		{
+			name: "word with parameter and space delimiter",
+			src:  `\fs24 text`,
+			want: []token{
+				{kind: tokenControlWord, word: "fs", param: 24, hasParam: true},
+				{kind: tokenText, data: []byte("text")},
+			},
+		},
+		{
+			name: "negative parameter",
+			src:  `\u-10179?`,
+			want: []token{
+				{kind: tokenControlWord, word: "u", param: -10179, hasParam: true},
+				{kind: tokenText, data: []byte("?")},
+			},
+		},
+		{
+			name: "non-space delimiter belongs to stream",
+			src:  `\b;\i0\par`,
+			want: []token{
+				{kind: tokenControlWord, word: "b"},
+				{kind: tokenText, data: []byte(";")},
+				{kind: tokenControlWord, word: "i", param: 0, hasParam: true},
+				{kind: tokenControlWord, word: "par"},
+			},
+		},
+		{
+			name: "groups and control symbols",
+			src:  `{\*\x}\{\~`,
+			want: []token{
+				{kind: tokenGroupStart},
+				{kind: tokenControlSymbol, word: "*"},
+				{kind: tokenControlWord, word: "x"},
+				{kind: tokenGroupEnd},
+				{kind: tokenControlSymbol, word: "{"},
+				{kind: tokenControlSymbol, word: "~"},
+			},
+		},
+		{
+			name: "hex symbol",
+			src:  `\'e9\'CF`,
+			want: []token{
+				{kind: tokenControlSymbol, word: "'", data: []byte{0xe9}},
+				{kind: tokenControlSymbol, word: "'", data: []byte{0xcf}},
+			},
+		},
+		{
+			name: "line breaks are skipped",
+			src:  "a\r\nb\\\n",
+			want: []token{
+				{kind: tokenText, data: []byte("ab")},
+				{kind: tokenControlWord, word: "par"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tz := newTokenizer(strings.NewReader(tt.src))
+			var got []token
+			for {
+				tok, err := tz.next()
+				if err != nil {
+					break
+				}
+				got = append(got, tok)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// paragraphTexts returns text of the document body paragraphs
+func paragraphTexts(doc *Document) []string {
+	var res []string
+	for _, c := range doc.content {
+		if p, ok := c.(*Paragraph); ok {
+			res = append(res, paragraphText(p))
+		}
+	}
+	return res
+}
+
+func paragraphText(p *Paragraph) string {
+	var sb strings.Builder
+	for _, c := range p.content {
+		switch v := c.(type) {
+		case *Text:
+			sb.WriteString(v.content)
+		case *Field:
+			sb.WriteString(v.content)
+		}
+	}
+	return sb.String()
+}
+
+// exportBody returns exported document content following the document header
+func exportBody(doc *Document) string {
+	s := string(doc.Export())
+	return s[strings.Index(s, "\\fet2")+len("\\fet2"):]
+}
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"paragraphs", `{\rtf1 first\par second\par}`, []string{"first", "second"}},
+		{"not finished paragraph", `{\rtf1 text}`, []string{"text"}},
+		{"hex symbol", `{\rtf1\ansi caf\'e9\par}`, []string{"café"}},
+		{"escaped symbols", `{\rtf1 \{a\}\\b\~c\emdash\par}`, []string{"{a}\\b\u00a0c—"}},
+		{"unicode with fallback", `{\rtf1 \u1046?x\par}`, []string{"Жx"}},
+		{"unicode with uc", `{\rtf1\uc2 \u1046\'3f\'3fx\par}`, []string{"Жx"}},
+		{"uc is scoped to group", `{\rtf1{\uc0 \u1046}\u1046?\par}`, []string{"ЖЖ"}},
+		{"surrogate pair", `{\rtf1 \u-10179?\u-8704?\par}`, []string{"😀"}},
+		{"ignorable destination", `{\rtf1 a{\*\unknown hidden}b\par}`, []string{"ab"}},
+		{"skipped destination", `{\rtf1{\info{\title Title}}text\par}`, []string{"text"}},
+		{"field result", `{\rtf1{\field{\*\fldinst HYPERLINK "x"}{\fldrslt link}}\par}`, []string{"link"}},
+		{"cp1251 code page", `{\rtf1\ansi\ansicpg1251 \'cf\'f0\'e8\par}`, []string{"При"}},
+		{"cp1251 font charset", `{\rtf1\ansi{\fonttbl{\f0 Arial;}{\f1\fcharset204 Arial Cyr;}}\f1\'cf\'f0\'e8\par}`, []string{"При"}},
+		{"binary data is skipped", `{\rtf1 a\bin3 xyzb\par}`, []string{"ab"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := paragraphTexts(doc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want *Field // nil if result is kept as text
+	}{
+		{
+			name: "page with format",
+			src:  `{\field{\*\fldinst PAGE \\* roman \\* MERGEFORMAT}{\fldrslt iv}}`,
+			want: &Field{fieldType: FieldPage, format: FieldFormatRoman, Text: Text{content: "iv"}},
+		},
+		{
+			name: "date picture",
+			src:  `{\field{\*\fldinst {\f1 DATE \\@ "d \\"of\\" MMMM"}}{\fldrslt {\f1 5 of May}}}`,
+			want: &Field{fieldType: FieldDate, format: `d "of" MMMM`, Text: Text{content: "5 of May"}},
+		},
+		{
+			name: "hyperlink with escaped path",
+			src:  `{\field{\*\fldinst HYPERLINK "C:\\\\a\\\\b.rtf"}{\fldrslt {\ul file}{\ul  name}}}`,
+			want: &Field{fieldType: FieldHyperlink, argument: `C:\a\b.rtf`, Text: Text{content: "file name"}},
+		},
+		{
+			name: "internal link",
+			src:  `{\field{\*\fldinst HYPERLINK \\l "results"}{\fldrslt see}}`,
+			want: &Field{fieldType: FieldHyperlink, switches: "\\l", argument: "results", Text: Text{content: "see"}},
+		},
+		{
+			name: "unsupported field",
+			src:  `{\field{\*\fldinst TOC \\o}{\fldrslt contents}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(strings.NewReader(`{\rtf1 a` + tt.src + `b\par}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			content := doc.content[0].(*Paragraph).content
+			if tt.want == nil {
+				for _, c := range content {
+					if _, ok := c.(*Field); ok {
+						t.Errorf("unexpected field %+v", c)
+					}
+				}
+				return
+			}
+			if len(content) != 3 {
+				t.Fatalf("got %d items, want 3", len(content))
+			}
+			f, ok := content[1].(*Field)
+			if !ok {
+				t.Fatalf("field expected, got %+v", content[1])
+			}
+			if f.fieldType != tt.want.fieldType || f.switches != tt.want.switches || f.argument != tt.want.argument ||
+				f.format != tt.want.format || f.content != tt.want.content {
+				t.Errorf("got %+v, want %+v", f, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTables(t *testing.T) {
+	src := `{\rtf1{\fonttbl{\f0\froman\fprq2 Times New Roman;}{\f1\fswiss Arial;}}` +
+		`{\colortbl;\red255\green0\blue0;\red1\green2\blue3;}` +
+		`\f1\fs20\cf2 text\par` +
+		`\trowd\trleft100\clbrdrb\brdrw30\brdrdb\brdrcf1\cellx1100\cellx3100` +
+		`\pard\intbl a\cell\pard\intbl\qr b\cell\row}`
+	doc, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fonts := *doc.fontColor
+	if len(fonts) != 2 || fonts[0].code != FontTimesNewRoman || fonts[1].code != FontArial || fonts[0].family != "roman" {
+		t.Errorf("unexpected font table %+v", fonts)
+	}
+	colors := *doc.colorTable
+	if len(colors) != 2 || colors[0].name != ColorRed || colors[1].name != "color_010203" {
+		t.Errorf("unexpected color table %+v", colors)
+	}
+	txt := doc.content[0].(*Paragraph).content[0].(*Text)
+	if txt.fontCode != 1 || txt.fontSize != 10 || txt.colorCode != 2 {
+		t.Errorf("unexpected text formatting %+v", txt)
+	}
+
+	if len(doc.content) != 2 {
+		t.Fatalf("got %d items, want 2", len(doc.content))
+	}
+	table, ok := doc.content[1].(*Table)
+	if !ok || len(table.data) != 1 {
+		t.Fatalf("table with one row expected, got %+v", doc.content[1])
+	}
+	cells := table.data[0].cells
+	if len(cells) != 2 {
+		t.Fatalf("got %d cells, want 2", len(cells))
+	}
+	for i, want := range []struct {
+		width int
+		text  string
+		align string
+	}{
+		{1000, "a", AlignLeft},
+		{2000, "b", AlignRight},
+	} {
+		p := cells[i].content[0].(*Paragraph)
+		if cells[i].cellWidth != want.width || paragraphText(p) != want.text || p.align != want.align {
+			t.Errorf("cell %d: got width %d, text %q, align %q", i, cells[i].cellWidth, paragraphText(p), p.align)
+		}
+	}
+	bottom := cells[0].getBorderLine(sideBottom)
+	if !cells[0].borderBottom || bottom.style != BorderDouble || bottom.width != 30 || bottom.color != ColorRed {
+		t.Errorf("unexpected bottom border %+v", bottom)
+	}
+}
+
+func TestParseBinaryPicture(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 3))); err != nil {
+		t.Fatal(err)
+	}
+	var src bytes.Buffer
+	fmt.Fprintf(&src, `{\rtf1{\*\shppict{\pict\pngblip\picw2\pich3\bin%d`, buf.Len())
+	src.WriteString(" ")
+	src.Write(buf.Bytes())
+	src.WriteString(`}}\par}`)
+
+	doc, err := Parse(&src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pic, ok := doc.content[0].(*Paragraph).content[0].(*Picture)
+	if !ok {
+		t.Fatalf("picture expected, got %+v", doc.content[0].(*Paragraph).content)
+	}
+	if !bytes.Equal(pic.src, buf.Bytes()) || pic.format != ImageFormatPng {
+		t.Errorf("picture data is not restored")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`plain text`,
+		`{\pard text}`,
+		`{\rtf1 text`,
+		`{\rtf1 text\'e`,
+		`{\rtf1\bin10 abc}`,
+		`{\rtf1\bin-1 }`,
+		`{\rtf1\bin999999999 }`,
+		`{\rtf1\b99999999999999999999999 }`,
+		`{\rtf1\` + strings.Repeat("a", 40) + `}`,
+	} {
+		if _, err := Parse(strings.NewReader(src)); err == nil {
+			t.Errorf("error expected for %q", src)
+		}
+	}
+}
+
+func TestExportParseRoundTrip(t *testing.T) {
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+
+	doc := NewDocument().SetFootnoteNumbering(ListFormatLowerRoman)
+	doc.SetColumns(2).SetLineBetweenColumns(true)
+	doc.AddHeader(HeaderFooterAll).AddParagraph().AddText("Letterhead", 10, FontArial, ColorGray)
+	doc.AddFooter(HeaderFooterFirst).AddParagraph().AddText("Confidential", 8, FontArial, ColorRed)
+
+	p := doc.AddParagraph().SetAlign(AlignJustify).SetSpaceAfter(120).AddTabStop(3000, AlignRight, TabLeaderDots)
+	p.AddText("Bold", 12, FontTimesNewRoman, ColorBlack).SetBold()
+	p.AddTab()
+	p.AddText("Приветствие {escaped}", 14, FontArial, ColorBlue).SetItalic().SetUnderlining()
+	p.AddFootnote().AddParagraph().AddText("Footnote text", 10, FontArial, ColorBlack)
+	p.AddNewLine()
+	p.AddText("second line", 12, FontArial, ColorBlack)
+	p.AddHyperlink("", `C:\docs\"a".rtf`, 12, FontArial, ColorBlue)
+	p.AddInternalLink("results", "results", 12, FontArial, ColorBlue)
+	p.AddField(FieldPage, FieldOptions{Format: FieldFormatUpperRoman}).SetBold()
+	p.AddField(FieldDate, FieldOptions{Format: "dd.MM.yyyy", Placeholder: "01.02.2024"})
+	doc.AddParagraph().SetBorder(BorderDouble, 15, ColorRed).AddPicture(img.Bytes(), ImageFormatPng)
+
+	t1 := doc.AddTable().SetWidth(4000).SetBorderSide(BorderSideInsideV, BorderHairline, 5, ColorGray)
+	for i := 0; i < 2; i++ {
+		tr := t1.AddTableRow()
+		tr.AddDataCell(1000).SetBackgroundColor(ColorSilver).AddParagraph().AddText("cell", 12, FontArial, ColorBlack)
+		tr.AddDataCell(3000).SetVAlign(VAlignMiddle).AddParagraph().SetAlign(AlignCenter).AddText("wide", 12, FontArial, ColorBlack)
+	}
+	doc.AddParagraph().AddText("after table", 12, FontArial, ColorBlack)
+
+	s := doc.AddSection().SetOrientation(OrientationLandscape).SetPageNumberRestart(1)
+	s.AddHeader(HeaderFooterAll).AddParagraph().AddText("Section header", 10, FontArial, ColorGray)
+	s.AddParagraph().AddText("Landscape", 12, FontArial, ColorBlack)
+
+	out := doc.Export()
+	parsed, err := Parse(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again := parsed.Export(); !bytes.Equal(out, again) {
+		t.Errorf("export of parsed document differs:\n%s\n---\n%s", out, again)
+	}
+}
@@ -0,0 +1,191 @@
+package rtfdoc
+
+import (
+	"fmt"
+	"io"
+)
+
+// AddSection returns new Section, which starts from the new page. Section inherits current page settings
+// of the Document. Content added to Document itself belongs to the first section.
+func (doc *Document) AddSection() *Section {
+	s := Section{
+		orientation: doc.orientation,
+		margins:     doc.margins,
+		pageFormat:  doc.pageFormat,
+		pagesize:    doc.pagesize,
+		columnLayout: columnLayout{
+			columns:       doc.columns,
+			columnSpacing: doc.columnSpacing,
+			columnWidths:  append([]int(nil), doc.columnWidths...),
+			lineBetween:   doc.lineBetween,
+		},
+		generalSettings: generalSettings{
+			colorTable: doc.colorTable,
+			fontColor:  doc.fontColor,
+			styleSheet: doc.styleSheet,
+			listTable:  doc.listTable,
+		},
+	}
+	s.updateMaxWidth()
+	doc.sections = append(doc.sections, &s)
+	return &s
+}
+
+// getPageWidth returns width of the page between margins
+func (s *Section) getPageWidth() int {
+	return s.pagesize.width - s.marginRight - s.marginLeft
+}
+
+func (s *Section) updateMaxWidth() {
+	s.maxWidth = s.getColumnWidth(s.getPageWidth())
+}
+
+// GetMaxContentWidth returns width of the Section content area (width of the column in multi-column layout)
+func (s *Section) GetMaxContentWidth() int {
+	return s.maxWidth
+}
+
+// SetFormat sets Section page format (A2, A3, A4)
+func (s *Section) SetFormat(format string) *Section {
+	size, err := getSize(format, s.orientation)
+	if err == nil {
+		s.pageFormat = format
+		s.pagesize = size
+	}
+	s.updateMaxWidth()
+	return s
+}
+
+// SetOrientation sets Section page orientation (portrait, landscape)
+func (s *Section) SetOrientation(orientation string) *Section {
+	for _, i := range []string{OrientationLandscape, OrientationPortrait} {
+		if orientation == i {
+			s.orientation = i
+		}
+	}
+	size, err := getSize(s.pageFormat, s.orientation)
+	if err == nil {
+		s.pagesize = size
+	}
+	s.updateMaxWidth()
+	return s
+}
+
+// SetMarginLeft sets left margin for Section work area
+func (s *Section) SetMarginLeft(value int) *Section {
+	s.marginLeft = value
+	s.updateMaxWidth()
+	return s
+}
+
+// SetMarginRight sets right margin for Section work area
+func (s *Section) SetMarginRight(value int) *Section {
+	s.marginRight = value
+	s.updateMaxWidth()
+	return s
+}
+
+// SetMarginTop sets top margin for Section work area
+func (s *Section) SetMarginTop(value int) *Section {
+	s.marginTop = value
+	return s
+}
+
+// SetMarginBottom sets bottom margin for Section work area
+func (s *Section) SetMarginBottom(value int) *Section {
+	s.marginBottom = value
+	return s
+}
+
+// SetPageNumberRestart restarts page numbering of the Section from start value
+func (s *Section) SetPageNumberRestart(start int) *Section {
+	if start > 0 {
+		s.pageNumberStart = start
+	}
+	return s
+}
+
+// AddHeader returns page header of the Section. Sections without own headers inherit headers of the previous one.
+func (s *Section) AddHeader(kind string) *HeaderFooter {
+	hf, headers := getHeaderFooter(s.headers, false, kind, s.generalSettings, s.getPageWidth())
+	s.headers = headers
+	return hf
+}
+
+// AddFooter returns page footer of the Section. Sections without own footers inherit footers of the previous one.
+func (s *Section) AddFooter(kind string) *HeaderFooter {
+	hf, headers := getHeaderFooter(s.headers, true, kind, s.generalSettings, s.getPageWidth())
+	s.headers = headers
+	return hf
+}
+
+// AddParagraph returns new Paragraph of the Section
+func (s *Section) AddParagraph() *Paragraph {
+	p := newParagraph(s.generalSettings, s.maxWidth)
+	s.content = append(s.content, p)
+	return p
+}
+
+// AddPageBreak adds page break between section paragraphs
+func (s *Section) AddPageBreak() *Section {
+	s.content = append(s.content, &controlWord{word: "page"})
+	return s
+}
+
+// AddTable returns new Table of the Section
+func (s *Section) AddTable() *Table {
+	t := newTable(s.generalSettings, s.maxWidth)
+	s.content = append(s.content, t)
+	return t
+}
+
+// GetTableCellWidthByRatio returns slice of cell widths from cells ratios
+func (s *Section) GetTableCellWidthByRatio(tableWidth int, ratio ...float64) []int {
+	tw := tableWidth
+	if tw > s.maxWidth {
+		tw = s.maxWidth
+	}
+	cellRatioSum := 0.0
+	for _, cellRatio := range ratio {
+		cellRatioSum += cellRatio
+	}
+	var cellWidth = make([]int, len(ratio))
+	for i := range ratio {
+		cellWidth[i] = int(ratio[i] * (float64(tw) / cellRatioSum))
+	}
+	return cellWidth
+}
+
+func (s *Section) getSectionProperties() string {
+	res := "\n\\sect\\sectd"
+	if s.orientation == OrientationLandscape {
+		res += "\\lndscpsxn"
+	}
+	if s.pagesize != (size{}) {
+		res += fmt.Sprintf("\\pgwsxn%d\\pghsxn%d", s.pagesize.width, s.pagesize.height)
+	}
+	res += fmt.Sprintf("\n\\marglsxn%d\\margrsxn%d\\margtsxn%d\\margbsxn%d",
+		s.marginLeft,
+		s.marginRight,
+		s.marginTop,
+		s.marginBottom)
+	res += s.getColumnProperties()
+	if s.pageNumberStart > 0 {
+		res += fmt.Sprintf("\\pgnrestart\\pgnstarts%d", s.pageNumberStart)
+	}
+	return res
+}
+
+func (s Section) compose(w *rtfWriter) {
+	w.WriteString(s.getSectionProperties())
+	composeHeaders(w, s.headers)
+	for _, c := range s.content {
+		w.WriteString("\n")
+		c.compose(w)
+	}
+}
+
+// WriteTo streams Section to w. It implements io.WriterTo interface.
+func (s *Section) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, s.compose)
+}
@@ -0,0 +1,101 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSection(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(doc *Document)
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "section inherits document page settings",
+			build: func(doc *Document) {
+				doc.SetMarginLeft(1000)
+				doc.AddSection().AddParagraph().AddText("x", 12, FontArial, ColorBlack)
+			},
+			want:    []string{"\\sect\\sectd\\pgwsxn11952\\pghsxn16848\n\\marglsxn1000\\margrsxn720\\margtsxn720\\margbsxn720"},
+			notWant: []string{"\\lndscpsxn", "\\pgnrestart"},
+		},
+		{
+			name: "section page setup",
+			build: func(doc *Document) {
+				doc.AddSection().SetFormat(FormatA3).SetOrientation(OrientationLandscape).
+					SetMarginLeft(100).SetMarginRight(200).SetMarginTop(300).SetMarginBottom(400)
+			},
+			want: []string{"\\sect\\sectd\\lndscpsxn\\pgwsxn23904\\pghsxn16848\n\\marglsxn100\\margrsxn200\\margtsxn300\\margbsxn400"},
+		},
+		{
+			name: "unknown format and orientation are ignored",
+			build: func(doc *Document) {
+				doc.AddSection().SetFormat("unknown").SetOrientation("unknown")
+			},
+			want:    []string{"\\pgwsxn11952\\pghsxn16848"},
+			notWant: []string{"\\lndscpsxn"},
+		},
+		{
+			name: "page number restart",
+			build: func(doc *Document) {
+				doc.AddSection().SetPageNumberRestart(5)
+				doc.AddSection().SetPageNumberRestart(0)
+			},
+			want: []string{"\\pgnrestart\\pgnstarts5"},
+		},
+		{
+			name: "section headers",
+			build: func(doc *Document) {
+				s := doc.AddSection()
+				s.AddHeader(HeaderFooterFirst).AddParagraph().AddText("head", 12, FontArial, ColorBlack)
+				s.AddFooter(HeaderFooterAll).AddParagraph().AddText("foot", 12, FontArial, ColorBlack)
+			},
+			want: []string{"\\margbsxn720\n\\titlepg\n{\\headerf\n", "{\\footer\n"},
+		},
+		{
+			name: "section content follows document content",
+			build: func(doc *Document) {
+				doc.AddParagraph().AddText("first", 12, FontArial, ColorBlack)
+				s := doc.AddSection()
+				s.AddParagraph().AddText("second", 12, FontArial, ColorBlack)
+				s.AddPageBreak()
+				s.AddTable().AddTableRow().AddDataCell(1000)
+			},
+			want: []string{"first}}\\par\n\\sect\\sectd", "second}}\\par\n\n\\page ", "\\cellx1000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			body := exportBody(doc)
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("%q not found in\n%s", s, body)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(body, s) {
+					t.Errorf("unexpected %q in\n%s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestSectionMaxContentWidth(t *testing.T) {
+	doc := NewDocument()
+	s := doc.AddSection()
+	if got, want := s.GetMaxContentWidth(), doc.GetMaxContentWidth(); got != want {
+		t.Errorf("got width %d, want width of the document %d", got, want)
+	}
+	s.SetMarginLeft(1000).SetMarginRight(1000)
+	if got, want := s.GetMaxContentWidth(), 11952-2000; got != want {
+		t.Errorf("got width %d, want %d", got, want)
+	}
+	if got, want := s.GetTableCellWidthByRatio(20000, 1, 3), []int{(11952 - 2000) / 4, (11952 - 2000) * 3 / 4}; got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("got cell widths %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,267 @@
+package rtfdoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxStyleDepth limits base style chain, so that cyclic inheritance doesn't hang composing
+const maxStyleDepth = 16
+
+// NewStyleSheet returns new stylesheet for Document with Normal and Heading 1..6 styles
+func (doc *Document) NewStyleSheet() *StyleSheet {
+	ss := StyleSheet{}
+	doc.header.styleSheet = &ss
+
+	doc.AddParagraphStyle(StyleNormal, "").SetFont(FontTimesNewRoman).SetFontSize(12).SetAlign(AlignLeft)
+	for i, fontSize := range []int{16, 14, 13, 12, 11, 10} {
+		doc.AddParagraphStyle(fmt.Sprintf("heading %d", i+1), StyleNormal).
+			SetFontSize(fontSize).
+			SetBold().
+			SetOutlineLevel(i + 1).
+			SetNext(StyleNormal)
+	}
+	return &ss
+}
+
+// AddParagraphStyle adds paragraph style, which inherits formatting of basedOn style.
+// If the style with the same name exists, it is returned instead.
+func (doc *Document) AddParagraphStyle(name string, basedOn string) *Style {
+	return doc.addStyle(name, basedOn, false)
+}
+
+// AddCharacterStyle adds character style, which inherits formatting of basedOn character style.
+// If the style with the same name exists, it is returned instead.
+func (doc *Document) AddCharacterStyle(name string, basedOn string) *Style {
+	return doc.addStyle(name, basedOn, true)
+}
+
+func (doc *Document) addStyle(name string, basedOn string, isCharacter bool) *Style {
+	ss := doc.styleSheet
+	if _, s := ss.find(name); s != nil {
+		return s
+	}
+	s := Style{
+		name:        name,
+		isCharacter: isCharacter,
+		basedOn:     basedOn,
+		generalSettings: generalSettings{
+			colorTable: doc.colorTable,
+			fontColor:  doc.fontColor,
+			styleSheet: ss,
+		},
+	}
+	if !isCharacter {
+		s.next = name
+	}
+	*ss = append(*ss, &s)
+	return &s
+}
+
+// find returns index and style by name
+func (ss *StyleSheet) find(name string) (int, *Style) {
+	if ss == nil {
+		return -1, nil
+	}
+	for i, s := range *ss {
+		if s.name == name {
+			return i, s
+		}
+	}
+	return -1, nil
+}
+
+// SetNext sets style of the paragraph following paragraph of this style
+func (s *Style) SetNext(name string) *Style {
+	s.next = name
+	return s
+}
+
+// SetAlign sets paragraph align of the style (c/center, l/left, r/right, j/justify)
+func (s *Style) SetAlign(align string) *Style {
+	for _, i := range []string{
+		AlignCenter,
+		AlignLeft,
+		AlignRight,
+		AlignJustify,
+		AlignDistribute,
+	} {
+		if i == align {
+			s.align = i
+		}
+	}
+	return s
+}
+
+// SetOutlineLevel sets outline level (1 - 9) of the paragraph style, used by navigation pane and table of contents
+func (s *Style) SetOutlineLevel(level int) *Style {
+	if level >= 1 && level <= 9 {
+		s.outlineLevel = level
+	}
+	return s
+}
+
+// SetFont sets style font
+func (s *Style) SetFont(fontCode string) *Style {
+	s.fontCode = fontCode
+	return s
+}
+
+// SetFontSize sets style font size
+func (s *Style) SetFontSize(fontSize int) *Style {
+	s.fontSize = fontSize
+	return s
+}
+
+// SetColor sets style text color
+func (s *Style) SetColor(colorCode string) *Style {
+	s.colorCode = colorCode
+	return s
+}
+
+// SetBold sets style text to Bold
+func (s *Style) SetBold() *Style {
+	s.isBold = true
+	return s
+}
+
+// SetItalic sets style text to Italic
+func (s *Style) SetItalic() *Style {
+	s.isItalic = true
+	return s
+}
+
+// SetUnderlining sets style text to Underlining
+func (s *Style) SetUnderlining() *Style {
+	s.isUnderlining = true
+	return s
+}
+
+// resolve returns style formatting merged with formatting of its base styles
+func (s Style) resolve() Style {
+	res := s
+	base := s.basedOn
+	for depth := 0; base != "" && depth < maxStyleDepth; depth++ {
+		_, b := s.styleSheet.find(base)
+		if b == nil || b.isCharacter != s.isCharacter {
+			break
+		}
+		if res.align == "" {
+			res.align = b.align
+		}
+		if res.outlineLevel == 0 {
+			res.outlineLevel = b.outlineLevel
+		}
+		if res.fontCode == "" {
+			res.fontCode = b.fontCode
+		}
+		if res.fontSize == 0 {
+			res.fontSize = b.fontSize
+		}
+		if res.colorCode == "" {
+			res.colorCode = b.colorCode
+		}
+		res.isBold = res.isBold || b.isBold
+		res.isItalic = res.isItalic || b.isItalic
+		res.isUnderlining = res.isUnderlining || b.isUnderlining
+		base = b.basedOn
+	}
+	return res
+}
+
+// paragraphProperties returns paragraph formatting control words of the resolved style
+func (s Style) paragraphProperties() string {
+	var res strings.Builder
+	if s.align != "" {
+		res.WriteString(fmt.Sprintf("\\q%s", s.align))
+	}
+	if s.outlineLevel > 0 {
+		res.WriteString(fmt.Sprintf("\\outlinelevel%d", s.outlineLevel-1))
+	}
+	return res.String()
+}
+
+// charProperties returns character formatting control words of the resolved style
+func (s Style) charProperties() string {
+	var res strings.Builder
+	if s.fontCode != "" {
+		for i, f := range *s.fontColor {
+			if f.code == s.fontCode {
+				res.WriteString(fmt.Sprintf("\\f%d", i))
+				break
+			}
+		}
+	}
+	if s.fontSize > 0 {
+		res.WriteString(fmt.Sprintf("\\fs%d", s.fontSize*2))
+	}
+	if s.colorCode != "" {
+		for i, c := range *s.colorTable {
+			if c.name == s.colorCode {
+				res.WriteString(fmt.Sprintf("\\cf%d", i+1))
+				break
+			}
+		}
+	}
+	if s.isBold {
+		res.WriteString("\\b")
+	}
+	if s.isItalic {
+		res.WriteString("\\i")
+	}
+	if s.isUnderlining {
+		res.WriteString("\\ul")
+	}
+	return res.String()
+}
+
+func (s Style) encode(index int) string {
+	r := s.resolve()
+	res := ""
+	if s.isCharacter {
+		res = fmt.Sprintf("{\\*\\cs%d\\additive%s", index, r.charProperties())
+	} else {
+		res = fmt.Sprintf("{\\s%d%s%s", index, r.paragraphProperties(), r.charProperties())
+	}
+	if i, _ := s.styleSheet.find(s.basedOn); i >= 0 {
+		res += fmt.Sprintf("\\sbasedon%d", i)
+	}
+	if i, _ := s.styleSheet.find(s.next); i >= 0 {
+		res += fmt.Sprintf("\\snext%d", i)
+	}
+	return res + fmt.Sprintf(" %s;}", escapeName(s.name))
+}
+
+func (ss StyleSheet) encode() string {
+	var res strings.Builder
+	for i := range ss {
+		res.WriteString(ss[i].encode(i))
+	}
+	return res.String()
+}
+
+// SetStyle sets paragraph style by name. Style align replaces Paragraph align. Style font, size and color
+// are used by the text added with zero size and empty font or color.
+func (par *Paragraph) SetStyle(name string) *Paragraph {
+	_, s := par.styleSheet.find(name)
+	if s == nil || s.isCharacter {
+		return par
+	}
+	par.style = name
+	if r := s.resolve(); r.align != "" {
+		par.align = r.align
+		par.alignSet = true
+	}
+	return par
+}
+
+// SetStyle sets character style of the text by name. Font, size and color given to AddText or
+// Text setters take precedence over style.
+func (text *Text) SetStyle(name string) *Text {
+	_, s := text.styleSheet.find(name)
+	if s == nil || !s.isCharacter {
+		return text
+	}
+	text.style = name
+	return text
+}
@@ -0,0 +1,155 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextStyleFormatting(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(doc *Document)
+		want  string
+	}{
+		{
+			name: "AddText formatting differs from paragraph style",
+			build: func(doc *Document) {
+				doc.AddParagraph().SetStyle(StyleNormal).AddText("x", 16, FontArial, ColorRed)
+			},
+			want: "{\\f2\\fs32\\cf7 x}",
+		},
+		{
+			name: "empty AddText formatting takes paragraph style",
+			build: func(doc *Document) {
+				doc.AddParagraph().SetStyle(StyleHeading1).AddText("x", 0, "", ColorBlack)
+			},
+			want: "{\\cf1 x}",
+		},
+		{
+			name: "character style",
+			build: func(doc *Document) {
+				doc.AddCharacterStyle("Accent", "").SetBold().SetColor(ColorRed)
+				doc.AddParagraph().AddText("x", 12, FontArial, "").SetStyle("Accent")
+			},
+			want: "{\\cs7\\cf7\\b\\f2\\fs24 x}",
+		},
+		{
+			name: "setters take precedence over style",
+			build: func(doc *Document) {
+				doc.AddCharacterStyle("Accent", "").SetFont(FontArial).SetColor(ColorRed)
+				doc.AddParagraph().AddText("x", 0, "", "").SetStyle("Accent").SetColor(ColorBlue)
+			},
+			want: "{\\cs7\\f2\\cf7\\cf2 x}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			if body := exportBody(doc); !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+		})
+	}
+}
+
+func TestStyleEncode(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(doc *Document) *Style
+		want  string
+	}{
+		{
+			name:  "default heading",
+			build: func(doc *Document) *Style { _, s := doc.styleSheet.find(StyleHeading2); return s },
+			want:  "{\\s2\\ql\\outlinelevel1\\f0\\fs28\\b\\sbasedon0\\snext0 heading 2;}",
+		},
+		{
+			name: "paragraph style inherits base style",
+			build: func(doc *Document) *Style {
+				doc.AddParagraphStyle("Base", "").SetAlign(AlignRight).SetColor(ColorRed).SetItalic()
+				return doc.AddParagraphStyle("Quote", "Base").SetFontSize(10).SetUnderlining().SetAlign("unknown")
+			},
+			want: "{\\s8\\qr\\fs20\\cf7\\i\\ul\\sbasedon7\\snext8 Quote;}",
+		},
+		{
+			name: "character style",
+			build: func(doc *Document) *Style {
+				return doc.AddCharacterStyle("Strong", "").SetBold().SetFont(FontArial)
+			},
+			want: "{\\*\\cs7\\additive\\f2\\b Strong;}",
+		},
+		{
+			name: "character style doesn't inherit paragraph style",
+			build: func(doc *Document) *Style {
+				return doc.AddCharacterStyle("Strong", StyleHeading1).SetItalic()
+			},
+			want: "{\\*\\cs7\\additive\\i\\sbasedon1 Strong;}",
+		},
+		{
+			name: "next style and outline level",
+			build: func(doc *Document) *Style {
+				return doc.AddParagraphStyle("Title", StyleNormal).SetOutlineLevel(1).SetOutlineLevel(10).SetNext(StyleHeading1)
+			},
+			want: "{\\s7\\ql\\outlinelevel0\\f0\\fs24\\sbasedon0\\snext1 Title;}",
+		},
+		{
+			name: "existing style is returned",
+			build: func(doc *Document) *Style {
+				return doc.AddParagraphStyle(StyleNormal, StyleHeading1)
+			},
+			want: "{\\s0\\ql\\f0\\fs24\\snext0 Normal;}",
+		},
+		{
+			name: "cyclic base styles",
+			build: func(doc *Document) *Style {
+				doc.AddParagraphStyle("A", "B").SetBold()
+				return doc.AddParagraphStyle("B", "A").SetItalic()
+			},
+			want: "{\\s8\\b\\i\\sbasedon7\\snext8 B;}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			s := tt.build(doc)
+			i, _ := doc.styleSheet.find(s.name)
+			if got := s.encode(i); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if header := string(doc.Export()); !strings.Contains(header, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, header)
+			}
+		})
+	}
+}
+
+func TestParagraphStyle(t *testing.T) {
+	doc := NewDocument()
+	doc.AddCharacterStyle("Strong", "")
+	tests := []struct {
+		name      string
+		style     string
+		wantStyle string
+		wantAlign string
+		wantText  string // style set by Text.SetStyle
+	}{
+		{"paragraph style", StyleHeading1, StyleHeading1, AlignLeft, ""},
+		{"unknown style is ignored", "unknown", "", AlignCenter, ""},
+		{"character style is set to text only", "Strong", "", AlignCenter, "Strong"},
+	}
+	for _, tt := range tests {
+		p := doc.AddParagraph().SetStyle(tt.style)
+		if p.style != tt.wantStyle || p.align != tt.wantAlign {
+			t.Errorf("%s: got style %q, align %q, want %q, %q", tt.name, p.style, p.align, tt.wantStyle, tt.wantAlign)
+		}
+		text := p.AddText("x", 12, FontArial, ColorBlack).SetStyle(tt.style)
+		if text.style != tt.wantText {
+			t.Errorf("%s: got text style %q, want %q", tt.name, text.style, tt.wantText)
+		}
+	}
+	body := exportBody(doc)
+	if want := "\\pard \\s1\\outlinelevel0 \\ql \\fi0 \\li0 \\ri0 {\\f0\\fs32\\b"; !strings.Contains(body, want) {
+		t.Errorf("%q not found in\n%s", want, body)
+	}
+}
@@ -2,7 +2,8 @@ package rtfdoc
 
 import (
 	"fmt"
-	"strings"
+	"io"
+	"log"
 )
 
 // SetDefaultFontSize sets default font size of Table
@@ -11,9 +12,13 @@ func (t *Table) SetDefaultFontSize(size int) *Table {
 	return t
 }
 
-// SetMarginLeft function sets Table left margin
+// SetMarginLeft function sets Table left margin, it is written as indent of the Table rows
 func (t *Table) SetMarginLeft(value int) *Table {
 	t.marginLeft = value
+	t.explicitMarginLeft = true
+	for tr := range t.data {
+		t.data[tr].indent = value
+	}
 	return t
 }
 
@@ -23,16 +28,17 @@ func (t *Table) SetMarginRight(value int) *Table {
 	return t
 }
 
-// SetMarginTop function sets Table top margin
+// SetMarginTop function sets Table top margin, it is written as space before the Table
 func (t *Table) SetMarginTop(value int) *Table {
 	t.marginTop = value
+	t.explicitMarginTop = true
 	return t
 }
 
-// SetMarginBottom function sets Table bottom margin
+// SetMarginBottom function sets Table bottom margin, it is written as space after the Table
 func (t *Table) SetMarginBottom(value int) *Table {
 	t.marginBottom = value
-	//tp.margins += fmt.Sprintf(" \\trpaddb%d", value)
+	t.explicitMarginBottom = true
 	return t
 }
 
@@ -78,14 +84,24 @@ func (t *Table) SetAlign(align string) *Table {
 
 // AddTable returns Table instance
 func (doc *Document) AddTable() *Table {
+	t := newTable(doc.generalSettings, doc.maxWidth)
+	doc.content = append(doc.content, t)
+	return t
+}
+
+// newTable returns Table with default margins and borders, which fits into docWidth
+func newTable(gs generalSettings, docWidth int) *Table {
 	t := Table{
-		align:    AlignCenter,
-		docWidth: doc.maxWidth,
+		align:     AlignCenter,
+		docWidth:  docWidth,
+		nestLevel: 1,
 	}
-	t.SetMarginLeft(100).SetMarginRight(100).SetMarginTop(100).SetMarginBottom(100)
+	t.marginLeft, t.marginRight, t.marginTop, t.marginBottom = 100, 100, 100, 100
 
-	t.colorTable = doc.colorTable
-	t.fontColor = doc.fontColor
+	t.colorTable = gs.colorTable
+	t.fontColor = gs.fontColor
+	t.styleSheet = gs.styleSheet
+	t.listTable = gs.listTable
 	t.SetBorderLeft(true).
 		SetBorderRight(true).
 		SetBorderTop(true).
@@ -94,7 +110,6 @@ func (doc *Document) AddTable() *Table {
 		SetBorderColor(ColorBlack).
 		SetBorderWidth(15)
 	t.updateMaxWidth()
-	doc.content = append(doc.content, &t)
 	return &t
 }
 
@@ -103,23 +118,206 @@ func (t *Table) updateMaxWidth() *Table {
 	return t
 }
 
-func (t Table) compose() string {
-	var res strings.Builder
+func (t Table) compose(w *rtfWriter) {
 	var align = ""
 	if t.align != "" {
 		align = fmt.Sprintf("\\trq%s", t.align)
 	}
-	for _, tr := range t.data {
-		res.WriteString(fmt.Sprintf("\n{\\trowd %s", align))
-		if t.defaultFontSize > 0 {
-			res.WriteString(fmt.Sprintf("\\fs%d", 2*t.defaultFontSize))
+	rows := t.closeSpans(t.data)
+	if t.style != nil {
+		rows = t.style.styleRows(rows)
+	}
+	if t.nestLevel <= 1 && !t.isFloating && t.explicitMarginTop {
+		composeTableSpace(w, t.marginTop)
+	}
+	var widths []int
+	if t.autoFit {
+		styled := t
+		styled.data = rows
+		widths = styled.fitColumns()
+	}
+	for i, tr := range rows {
+		if t.autoFit {
+			tr = tr.withWidths(widths)
+		}
+		tr = t.resolveBorders(tr, i == 0, i == len(rows)-1)
+		if t.nestLevel > 1 {
+			// Properties of nested row follow its cells
+			tr.encodeData(w)
+			w.WriteString("\n{\\*\\nesttableprops")
+			t.composeRowProperties(w, tr, align)
+			w.WriteString("\\nestrow}{\\nonesttables\\par}")
+			continue
+		}
+		w.WriteString("\n{")
+		t.composeRowProperties(w, tr, align)
+		tr.encodeData(w)
+		if tr.hasNestedTables() {
+			// Row with nested tables is defined once again at the end, as Word does
+			w.WriteString("\n")
+			t.composeRowProperties(w, tr, align)
+		}
+		w.WriteString("\\row}")
+	}
+	if t.nestLevel <= 1 && !t.isFloating && t.explicitMarginBottom {
+		composeTableSpace(w, t.marginBottom)
+	}
+}
+
+// closeSpans returns rows, which cells spanned only into not added rows aren't merged vertically
+func (t Table) closeSpans(rows []*TableRow) []*TableRow {
+	if len(t.spans) == 0 {
+		return rows
+	}
+	log.Println("Cell spans more rows than the Table has")
+	open := map[*TableCell]bool{}
+	for _, s := range t.spans {
+		if !s.placed {
+			open[s.origin] = true
+		}
+	}
+	if len(open) == 0 {
+		return rows
+	}
+	res := make([]*TableRow, len(rows))
+	for i, tr := range rows {
+		r := *tr
+		r.cells = make([]*TableCell, len(tr.cells))
+		for j, tc := range tr.cells {
+			c := *tc
+			if open[tc] || (tc.mergeOrigin != nil && open[tc.mergeOrigin]) {
+				c.verticalMerged = ""
+			}
+			r.cells[j] = &c
+		}
+		res[i] = &r
+	}
+	return res
+}
+
+// composeTableSpace writes empty paragraph of exact height as space before or after the Table
+func composeTableSpace(w *rtfWriter, height int) {
+	if height > 0 {
+		w.printf("\n\\pard \\sl-%d\\slmult0 \\par", height)
+	}
+}
+
+// resolveBorders returns copy of the row, which cells take outer lines of the Table and the row at the edges
+// and inside lines between cells. Lines set on cells themselves take precedence.
+func (t Table) resolveBorders(tr *TableRow, isFirst bool, isLast bool) *TableRow {
+	r := *tr
+	for i := range r.sides {
+		if r.sides[i].style == "" {
+			r.sides[i] = t.sides[i]
+		}
+	}
+	top, bottom := r.sides[sideInsideH], r.sides[sideInsideH]
+	if isFirst || tr.sides[sideTop].style != "" {
+		top = r.sides[sideTop]
+	}
+	if isLast || tr.sides[sideBottom].style != "" {
+		bottom = r.sides[sideBottom]
+	}
+	r.cells = make([]*TableCell, len(tr.cells))
+	for j, tc := range tr.cells {
+		c := *tc.merged()
+		left, right := r.sides[sideInsideV], r.sides[sideInsideV]
+		if j == 0 {
+			left = r.sides[sideLeft]
+		}
+		if j == len(tr.cells)-1 {
+			right = r.sides[sideRight]
+		}
+		for side, line := range [4]borderLine{left, right, top, bottom} {
+			if line.style != "" && c.sides[side].style == "" {
+				c.setSide(side, line)
+			}
+		}
+		r.cells[j] = &c
+	}
+	return &r
+}
+
+func (t Table) composeRowProperties(w *rtfWriter, tr *TableRow, align string) {
+	w.printf("\\trowd %s", align)
+	if t.defaultFontSize > 0 {
+		w.printf("\\fs%d", 2*t.defaultFontSize)
+	}
+	w.printf("\n\\trpaddl%d \\trpaddr%d \\trpaddt%d \\trpaddb%d\n", t.paddingLeft, t.paddingRight, t.paddingTop, t.paddingBottom)
+	t.composeLayout(w)
+	tr.encodeProperties(w)
+}
+
+// composeLayout writes gap between cells, preferred width and floating position of the Table
+func (t Table) composeLayout(w *rtfWriter) {
+	var layout string
+	if t.cellGap > 0 {
+		layout += fmt.Sprintf("\\trgaph%d", t.cellGap)
+	}
+	if t.width > 0 {
+		layout += fmt.Sprintf("\\trwWidth%d\\trftsWidth3", t.width)
+	}
+	if t.isFloating {
+		layout += fmt.Sprintf("\\tph%s\\tpv%s\\tposx%d\\tposy%d", t.positionFromX, t.positionFromY, t.positionX, t.positionY)
+		layout += fmt.Sprintf("\\tdfrmtxtLeft%d\\tdfrmtxtRight%d\\tdfrmtxtTop%d\\tdfrmtxtBottom%d",
+			t.textDistance.marginLeft, t.textDistance.marginRight, t.textDistance.marginTop, t.textDistance.marginBottom)
+		if t.noOverlap {
+			layout += "\\tabsnoovrlp"
 		}
-		res.WriteString(fmt.Sprintf("\n\\trpaddl%d \\trpaddr%d \\trpaddt%d \\trpaddb%d\n", t.paddingLeft, t.paddingRight, t.paddingTop, t.paddingBottom))
-		//res += t.getMargins()
-		res.WriteString(tr.encode())
-		res.WriteString("\\row}")
 	}
-	return res.String()
+	if layout != "" {
+		w.printf("%s\n", layout)
+	}
+}
+
+// SetCellGap sets half of the space between text of adjacent cells in twips
+func (t *Table) SetCellGap(value int) *Table {
+	if value >= 0 {
+		t.cellGap = value
+	}
+	return t
+}
+
+// SetPosition makes Table floating with text wrapped around it. Position is measured in twips
+// from the page, margin or column (PositionFromPage, PositionFromMargin, PositionFromColumn) horizontally
+// and from the page, margin or paragraph (PositionFromPage, PositionFromMargin, PositionFromParagraph) vertically.
+// Distances from the surrounding text are set by SetTextDistance.
+func (t *Table) SetPosition(x int, y int, fromX string, fromY string) *Table {
+	if fromX != PositionFromPage && fromX != PositionFromMargin && fromX != PositionFromColumn {
+		return t
+	}
+	if fromY != PositionFromPage && fromY != PositionFromMargin && fromY != PositionFromParagraph {
+		return t
+	}
+	t.isFloating = true
+	t.positionX, t.positionY = x, y
+	t.positionFromX, t.positionFromY = fromX, fromY
+	return t
+}
+
+// SetTextDistance sets distances (in twips) of the floating Table from the surrounding text
+func (t *Table) SetTextDistance(left int, right int, top int, bottom int) *Table {
+	t.textDistance = margins{marginLeft: left, marginRight: right, marginTop: top, marginBottom: bottom}
+	return t
+}
+
+// SetOverlap allows (by default) or prevents overlapping of floating Table with other floating tables
+func (t *Table) SetOverlap(allow bool) *Table {
+	t.noOverlap = !allow
+	return t
+}
+
+// AddTable returns Table nested into the cell
+func (dc *TableCell) AddTable() *Table {
+	t := newTable(dc.generalSettings, dc.maxWidth)
+	t.nestLevel = dc.nestLevel + 1
+	dc.content = append(dc.content, t)
+	return t
+}
+
+// WriteTo streams Table to w. It implements io.WriterTo interface.
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, t.compose)
 }
 
 // AddTableRow returns new Table row instance
@@ -128,8 +326,15 @@ func (t *Table) AddTableRow() *TableRow {
 		generalSettings: generalSettings{
 			fontColor:  t.fontColor,
 			colorTable: t.colorTable,
+			styleSheet: t.styleSheet,
+			listTable:  t.listTable,
 		},
 		tableWidth: t.maxWidth,
+		nestLevel:  t.nestLevel,
+		spans:      &t.spans,
+	}
+	if t.explicitMarginLeft {
+		tr.indent = t.marginLeft
 	}
 	tr.SetBorderLeft(t.borderLeft).
 		SetBorderRight(t.borderRight).
@@ -140,9 +345,24 @@ func (t *Table) AddTableRow() *TableRow {
 		SetBorderWidth(t.borderWidth)
 	t.updateMaxWidth()
 	t.data = append(t.data, &tr)
+	tr.addSpannedCells()
 	return &tr
 }
 
+// withWidths returns copy of the row, which cells have the given widths
+func (tr *TableRow) withWidths(widths []int) *TableRow {
+	r := *tr
+	r.cells = make([]*TableCell, len(tr.cells))
+	for i, tc := range tr.cells {
+		c := *tc
+		if i < len(widths) {
+			c.cellWidth = widths[i]
+		}
+		r.cells[i] = &c
+	}
+	return &r
+}
+
 func (tr *TableRow) updateMaxWidth() *TableRow {
 	tr.maxWidth = tr.tableWidth
 	return tr
@@ -180,40 +400,10 @@ func (t *Table) SetBorder(isBorder bool) *Table {
 
 // SetBorderStyle function sets Table left border style
 func (t *Table) SetBorderStyle(bStyle string) *Table {
-	for _, i := range []string{
-		BorderDashSmall,
-		BorderDashed,
-		BorderDotDash,
-		BorderDotDotDash,
-		BorderDotted,
-		BorderDouble,
-		BorderDoubleThickness,
-		BorderWavyDouble,
-		BorderEmboss,
-		BorderEngrave,
-		BorderHairline,
-		BorderInset,
-		BorderOutset,
-		BorderShadowed,
-		BorderSingleThickness,
-		BorderStripped,
-		BorderThickThinLarge,
-		BorderThickThinMedium,
-		BorderThickThinSmall,
-		BorderThinThickLarge,
-		BorderThinThickMedium,
-		BorderThinThickSmall,
-		BorderThinThickThinLarge,
-		BorderThinThickThinMedium,
-		BorderTriple,
-		BorderWavy,
-	} {
-		if bStyle == i {
-			t.borderStyle = i
-			for tr := range t.data {
-				t.data[tr].SetBorderStyle(i)
-			}
-			break
+	if isBorderStyle(bStyle) {
+		t.borderStyle = bStyle
+		for tr := range t.data {
+			t.data[tr].SetBorderStyle(bStyle)
 		}
 	}
 	return t
@@ -237,6 +427,17 @@ func (t *Table) SetBorderWidth(value int) *Table {
 	return t
 }
 
+// SetBorderSide sets style, width and color of the Table border side (BorderSideLeft, BorderSideInsideH, etc.).
+// Outer lines are drawn at the Table edges, inside lines between rows and cells. Lines of rows and cells take precedence.
+func (t *Table) SetBorderSide(side string, style string, width int, color string) *Table {
+	i := getSideIndex(side)
+	if i < 0 || !isBorderStyle(style) {
+		return t
+	}
+	t.setSide(i, borderLine{style: style, width: width, color: color})
+	return t
+}
+
 // SetWidth sets width of Table
 func (t *Table) SetWidth(width int) *Table {
 	t.width = width
@@ -274,40 +475,10 @@ func (tr *TableRow) SetBorder(isBorder bool) *TableRow {
 
 // SetBorderStyle function sets border style
 func (tr *TableRow) SetBorderStyle(bStyle string) *TableRow {
-	for _, i := range []string{
-		BorderDashSmall,
-		BorderDashed,
-		BorderDotDash,
-		BorderDotDotDash,
-		BorderDotted,
-		BorderDouble,
-		BorderDoubleThickness,
-		BorderWavyDouble,
-		BorderEmboss,
-		BorderEngrave,
-		BorderHairline,
-		BorderInset,
-		BorderOutset,
-		BorderShadowed,
-		BorderSingleThickness,
-		BorderStripped,
-		BorderThickThinLarge,
-		BorderThickThinMedium,
-		BorderThickThinSmall,
-		BorderThinThickLarge,
-		BorderThinThickMedium,
-		BorderThinThickSmall,
-		BorderThinThickThinLarge,
-		BorderThinThickThinMedium,
-		BorderTriple,
-		BorderWavy,
-	} {
-		if bStyle == i {
-			tr.borderStyle = i
-			for c := range tr.cells {
-				tr.cells[c].SetBorderStyle(i)
-			}
-			break
+	if isBorderStyle(bStyle) {
+		tr.borderStyle = bStyle
+		for c := range tr.cells {
+			tr.cells[c].SetBorderStyle(bStyle)
 		}
 	}
 	return tr
@@ -331,45 +502,124 @@ func (tr *TableRow) SetBorderWidth(value int) *TableRow {
 	return tr
 }
 
-func (tr *TableRow) encode() string {
-	var res strings.Builder
-	// Border settings
-	bTempl := "\n\\trbrdr%s\\brdrw%d\\brdr%s"
-	for c := range *tr.colorTable {
-		if ((*tr.colorTable)[c]).name == tr.borderColor {
-			bTempl += fmt.Sprintf("\\brdrcf%d", c+1)
-		}
+// SetHeader marks row as table header, which is repeated at the top of each page. Header rows must be the first rows of the Table.
+func (tr *TableRow) SetHeader(isHeader bool) *TableRow {
+	tr.isHeader = isHeader
+	return tr
+}
+
+// SetKeepTogether prevents row from being split by page break
+func (tr *TableRow) SetKeepTogether(keep bool) *TableRow {
+	tr.keepTogether = keep
+	return tr
+}
+
+// SetCantSplit is the same as SetKeepTogether (named after "cantSplit" row property of Word)
+func (tr *TableRow) SetCantSplit(cantSplit bool) *TableRow {
+	return tr.SetKeepTogether(cantSplit)
+}
+
+// SetKeepWithNext keeps row on the same page with the following row
+func (tr *TableRow) SetKeepWithNext(keep bool) *TableRow {
+	tr.keepWithNext = keep
+	return tr
+}
+
+// SetHeight sets row height in twips with rule RowHeightAtLeast or RowHeightExact. Zero height is automatic.
+func (tr *TableRow) SetHeight(height int, rule string) *TableRow {
+	if height < 0 {
+		return tr
+	}
+	switch rule {
+	case RowHeightAtLeast:
+		tr.height = height
+	case RowHeightExact:
+		tr.height = -height
+	}
+	return tr
+}
 
+// SetBorderSide sets style, width and color of the row border side (BorderSideLeft, BorderSideInsideV, etc.).
+// Left and right lines are drawn at the row edges, top and bottom ones along the whole row,
+// inside vertical lines between cells. Lines of cells take precedence.
+func (tr *TableRow) SetBorderSide(side string, style string, width int, color string) *TableRow {
+	i := getSideIndex(side)
+	if i < 0 || !isBorderStyle(style) {
+		return tr
 	}
+	tr.setSide(i, borderLine{style: style, width: width, color: color})
+	return tr
+}
 
-	if tr.borderLeft {
-		res.WriteString(fmt.Sprintf(bTempl, "l", tr.borderWidth, tr.borderStyle))
+func (tr *TableRow) encode(w *rtfWriter) {
+	tr.encodeProperties(w)
+	tr.encodeData(w)
+}
+
+func (tr *TableRow) encodeProperties(w *rtfWriter) {
+	if tr.isHeader {
+		w.WriteString("\\trhdr")
+	}
+	if tr.keepTogether {
+		w.WriteString("\\trkeep")
+	}
+	if tr.keepWithNext {
+		w.WriteString("\\trkeepfollow")
 	}
-	if tr.borderRight {
-		res.WriteString(fmt.Sprintf(bTempl, "r", tr.borderWidth, tr.borderStyle))
+	if tr.height != 0 {
+		w.printf("\\trrh%d", tr.height)
 	}
-	if tr.borderTop {
-		res.WriteString(fmt.Sprintf(bTempl, "t", tr.borderWidth, tr.borderStyle))
+	if tr.indent != 0 {
+		w.printf("\\trleft%d\\tblind%d\\tblindtype3", tr.indent, tr.indent)
+	}
+	// Border settings
+	tr.composeBorders(w, "trbrdr", tr.colorTable)
+	if tr.sides[sideInsideH].style != "" {
+		composeBorderLine(w, "trbrdrh", tr.sides[sideInsideH], tr.colorTable)
 	}
-	if tr.borderBottom {
-		res.WriteString(fmt.Sprintf(bTempl, "b", tr.borderWidth, tr.borderStyle))
+	if tr.sides[sideInsideV].style != "" {
+		composeBorderLine(w, "trbrdrv", tr.sides[sideInsideV], tr.colorTable)
 	}
 
 	if len(tr.cells) != 0 {
-		cellLengthPosition := 0
+		// Cell boundaries are measured from the left edge of the column
+		cellLengthPosition := tr.indent
 		for _, tc := range tr.cells {
 
 			cellLengthPosition += tc.getCellWidth()
-			res.WriteString(tc.cellComposeProperties())
-			res.WriteString(fmt.Sprintf("\\cellx%d", cellLengthPosition))
+			tc.merged().cellComposeProperties(w)
+			w.printf("\\cellx%d", cellLengthPosition)
 
 		}
-		res.WriteString("\n")
-		for _, tc := range tr.cells {
-			res.WriteString(tc.cellComposeData())
+		w.WriteString("\n")
+	}
+}
+
+func (tr *TableRow) encodeData(w *rtfWriter) {
+	for _, tc := range tr.cells {
+		tc.merged().cellComposeData(w)
+	}
+}
+
+func isParagraph(item documentItem) bool {
+	_, ok := item.(*Paragraph)
+	return ok
+}
+
+func (tr *TableRow) hasNestedTables() bool {
+	for _, tc := range tr.cells {
+		for _, c := range tc.content {
+			if _, ok := c.(*Table); ok {
+				return true
+			}
 		}
 	}
-	return res.String()
+	return false
+}
+
+// WriteTo streams TableRow to w. It implements io.WriterTo interface.
+func (tr *TableRow) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, tr.encode)
 }
 
 // AddDataCell returns new DataCell for current Table row
@@ -377,9 +627,12 @@ func (tr *TableRow) AddDataCell(width int) *TableCell {
 	dc := TableCell{
 		cellWidth: width,
 		maxWidth:  width,
+		nestLevel: tr.nestLevel,
 	}
 	dc.fontColor = tr.fontColor
 	dc.colorTable = tr.colorTable
+	dc.styleSheet = tr.styleSheet
+	dc.listTable = tr.listTable
 	dc.SetBorderLeft(tr.borderLeft).
 		SetBorderRight(tr.borderRight).
 		SetBorderTop(tr.borderTop).
@@ -389,9 +642,105 @@ func (tr *TableRow) AddDataCell(width int) *TableCell {
 		SetBorderWidth(tr.borderWidth)
 	dc.updateMaxWidth()
 	tr.cells = append(tr.cells, &dc)
+	tr.addSpannedCells()
 	return &dc
 }
 
+// AddDataCellSpan returns new DataCell, which takes colSpan columns of this row and rowSpan rows
+// starting from this one. Width is the whole width of spanned columns and is divided evenly between them.
+// Merged placeholder cells are added automatically, in the following rows they take the same columns,
+// so cells before it should be added first.
+func (tr *TableRow) AddDataCellSpan(width, colSpan, rowSpan int) *TableCell {
+	if colSpan < 1 {
+		colSpan = 1
+	}
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	widths := make([]int, colSpan)
+	for i := range widths {
+		widths[i] = width / colSpan
+	}
+	widths[colSpan-1] += width % colSpan
+
+	column := len(tr.cells)
+	// Placeholders of this row are added after the cell, so pending spans must not be inserted before them
+	spans := tr.spans
+	tr.spans = nil
+	dc := tr.AddDataCell(width)
+	dc.cellWidth = widths[0]
+	if colSpan > 1 {
+		dc.horizontalMerged = "gf"
+	}
+	if rowSpan > 1 {
+		dc.verticalMerged = "gf"
+	}
+	for _, w := range widths[1:] {
+		tr.cells = append(tr.cells, &TableCell{cellWidth: w, horizontalMerged: "rg", verticalMerged: dc.verticalMerged, mergeOrigin: dc})
+	}
+	tr.spans = spans
+	if rowSpan > 1 && tr.spans != nil {
+		*tr.spans = append(*tr.spans, &cellSpan{origin: dc, column: column, widths: widths, rowsLeft: rowSpan - 1})
+	}
+	tr.addSpannedCells()
+	return dc
+}
+
+// addSpannedCells adds placeholders of cells spanned from the previous rows, which start at the next column of the row
+func (tr *TableRow) addSpannedCells() {
+	if tr.spans == nil {
+		return
+	}
+	for added := true; added; {
+		added = false
+		column := len(tr.cells)
+		for i, s := range *tr.spans {
+			if s.rowsLeft == 0 || s.column != column {
+				continue
+			}
+			for j, w := range s.widths {
+				dc := TableCell{cellWidth: w, verticalMerged: "rg", mergeOrigin: s.origin}
+				if len(s.widths) > 1 {
+					dc.horizontalMerged = "rg"
+					if j == 0 {
+						dc.horizontalMerged = "gf"
+					}
+				}
+				tr.cells = append(tr.cells, &dc)
+			}
+			s.rowsLeft--
+			s.placed = true
+			if s.rowsLeft == 0 {
+				*tr.spans = append((*tr.spans)[:i], (*tr.spans)[i+1:]...)
+			}
+			added = true
+			break
+		}
+	}
+}
+
+func (tr *TableRow) getWidth() int {
+	width := 0
+	for _, tc := range tr.cells {
+		width += tc.getCellWidth()
+	}
+	return width
+}
+
+// merged returns cell to be written. Placeholder of the merged area takes properties of the first cell.
+func (dc *TableCell) merged() *TableCell {
+	if dc.mergeOrigin == nil {
+		return dc
+	}
+	c := *dc.mergeOrigin
+	c.cellWidth = dc.cellWidth
+	c.verticalMerged = dc.verticalMerged
+	c.horizontalMerged = dc.horizontalMerged
+	c.mergeOrigin = nil
+	c.content = nil
+	return &c
+}
+
 func (dc *TableCell) updateMaxWidth() *TableCell {
 	dc.maxWidth = dc.cellWidth - dc.marginLeft - dc.marginRight
 	return dc
@@ -405,81 +754,212 @@ func (dc *TableCell) SetWidth(cellWidth int) *TableCell {
 
 // AddParagraph creates cell's paragraph
 func (dc *TableCell) AddParagraph() *Paragraph {
+	p := dc.newParagraph()
+	dc.content = append(dc.content, p)
+	return p
+}
+
+func (dc *TableCell) newParagraph() *Paragraph {
+	align := AlignLeft
+	if dc.align != "" {
+		align = dc.align
+	}
 	p := Paragraph{
-		isTable: true,
-		align:   "l",
-		indent:  "\\fl360",
+		isTable:   true,
+		nestLevel: dc.nestLevel,
+		align:     align,
 		generalSettings: generalSettings{
 			colorTable: dc.colorTable,
 			fontColor:  dc.fontColor,
+			styleSheet: dc.styleSheet,
+			listTable:  dc.listTable,
 		},
 		allowedWidth: dc.maxWidth,
 	}
 	p.updateMaxWidth()
-	dc.content = append(dc.content, &p)
 	return &p
 }
 
-func (dc TableCell) cellComposeProperties() string {
-	var res strings.Builder
+func (dc TableCell) cellComposeProperties(w *rtfWriter) {
 	// Тута свойства ячейки (границы, все дела...)
-	bTempl := "\n\\clbrdr%s\\brdrw%d\\brdr%s"
-	for c := range *dc.colorTable {
-		if ((*dc.colorTable)[c]).name == dc.borderColor {
-			bTempl += fmt.Sprintf("\\brdrcf%d", c+1)
-		}
-
+	dc.composeBorders(w, "clbrdr", dc.colorTable)
+	if dc.diagonalDown.style != "" {
+		composeBorderLine(w, "cldglu", dc.diagonalDown, dc.colorTable)
 	}
-
-	if dc.borderLeft {
-		res.WriteString(fmt.Sprintf(bTempl, "l", dc.borderWidth, dc.borderStyle))
-	}
-	if dc.borderRight {
-		res.WriteString(fmt.Sprintf(bTempl, "r", dc.borderWidth, dc.borderStyle))
-	}
-	if dc.borderTop {
-		res.WriteString(fmt.Sprintf(bTempl, "t", dc.borderWidth, dc.borderStyle))
-	}
-	if dc.borderBottom {
-		res.WriteString(fmt.Sprintf(bTempl, "b", dc.borderWidth, dc.borderStyle))
+	if dc.diagonalUp.style != "" {
+		composeBorderLine(w, "cldgll", dc.diagonalUp, dc.colorTable)
 	}
 
 	// Margins
-	res.WriteString(fmt.Sprintf("\n\\clpadl%d\\clpadr%d\\clpadt%d\\clpadb%d",
+	w.printf("\n\\clpadl%d\\clpadr%d\\clpadt%d\\clpadb%d",
 		dc.paddingLeft, dc.paddingRight, dc.paddingTop, dc.paddingBottom,
-	))
+	)
+	// Paddings are used only with units set (3 is twips)
+	for _, p := range []struct {
+		side  string
+		value int
+	}{{"l", dc.paddingLeft}, {"r", dc.paddingRight}, {"t", dc.paddingTop}, {"b", dc.paddingBottom}} {
+		if p.value != 0 {
+			w.printf("\\clpadf%s3", p.side)
+		}
+	}
+
+	// Horizontal Merged
+	if dc.horizontalMerged != "" {
+		w.printf("\\clm%s", dc.horizontalMerged)
+	}
 
 	// Vertical Merged
 	if dc.verticalMerged != "" {
-		res.WriteString(fmt.Sprintf("\\clvm%s", dc.verticalMerged))
+		w.printf("\\clvm%s", dc.verticalMerged)
 	}
 
 	// Aligning insite cell
-	res.WriteString(fmt.Sprintf("\\clvertal%s", dc.vTextAlign))
+	w.printf("\\clvertal%s", dc.vTextAlign)
+
+	if dc.textFlow != "" {
+		w.printf("\\cltx%s", dc.textFlow)
+	}
+	if dc.noWrap {
+		w.WriteString("\\clNoWrap")
+	}
+	if dc.fitText {
+		w.WriteString("\\clFitText")
+	}
 
 	// Background Color
 
 	if dc.backgroundColor != "" {
 		for c := range *dc.colorTable {
 			if ((*dc.colorTable)[c]).name == dc.backgroundColor {
-				res.WriteString(fmt.Sprintf("\\clcbpat%d", c+1))
+				w.printf("\\clcbpat%d", c+1)
 			}
 		}
 	}
+}
 
-	return res.String()
+func (dc TableCell) cellComposeData(w *rtfWriter) {
+	// Cell ends with the paragraph of its own nesting level
+	content := dc.content
+	if len(content) == 0 || !isParagraph(content[len(content)-1]) {
+		content = append(append([]documentItem(nil), content...), dc.newParagraph())
+	}
+	for i, c := range content {
+		c.compose(w)
+		if isParagraph(c) {
+			if i < len(content)-1 {
+				w.WriteString("\\par")
+			}
+			w.WriteString("\n")
+		}
+	}
+	if dc.nestLevel > 1 {
+		w.WriteString("\\nestcell")
+		return
+	}
+	w.WriteString("\\cell")
 }
 
-func (dc TableCell) cellComposeData() string {
-	var res strings.Builder
-	if len(dc.content) == 0 {
-		dc.AddParagraph()
+// WriteTo streams TableCell data to w. It implements io.WriterTo interface.
+func (dc *TableCell) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, dc.cellComposeData)
+}
+
+// getBorderLine returns line of the side (sideLeft, etc.)
+func (b borders) getBorderLine(side int) borderLine {
+	if b.sides[side].style != "" {
+		return b.sides[side]
 	}
-	for _, p := range dc.content {
-		res.WriteString(fmt.Sprintf("%s\n", p.compose()))
+	return borderLine{style: b.borderStyle, width: b.borderWidth, color: b.borderColor}
+}
+
+// setSide sets line of the side, outer side becomes visible
+func (b *borders) setSide(side int, line borderLine) {
+	b.sides[side] = line
+	switch side {
+	case sideLeft:
+		b.borderLeft = true
+	case sideRight:
+		b.borderRight = true
+	case sideTop:
+		b.borderTop = true
+	case sideBottom:
+		b.borderBottom = true
+	}
+}
+
+// isBorderStyle reports whether style is one of the border styles (BorderSingleThickness, etc.)
+func isBorderStyle(style string) bool {
+	for _, i := range []string{
+		BorderDashSmall,
+		BorderDashed,
+		BorderDotDash,
+		BorderDotDotDash,
+		BorderDotted,
+		BorderDouble,
+		BorderDoubleThickness,
+		BorderWavyDouble,
+		BorderEmboss,
+		BorderEngrave,
+		BorderHairline,
+		BorderInset,
+		BorderOutset,
+		BorderShadowed,
+		BorderSingleThickness,
+		BorderStripped,
+		BorderThickThinLarge,
+		BorderThickThinMedium,
+		BorderThickThinSmall,
+		BorderThinThickLarge,
+		BorderThinThickMedium,
+		BorderThinThickSmall,
+		BorderThinThickThinLarge,
+		BorderThinThickThinMedium,
+		BorderTriple,
+		BorderWavy,
+	} {
+		if style == i {
+			return true
+		}
+	}
+	return false
+}
+
+// getSideIndex returns index of the side (BorderSideLeft, etc.) or -1 for unknown side
+func getSideIndex(side string) int {
+	for i, s := range []string{BorderSideLeft, BorderSideRight, BorderSideTop, BorderSideBottom, BorderSideInsideH, BorderSideInsideV} {
+		if side == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// composeBorders writes visible borders, which control words start with prefix (e.g. "clbrdr")
+func (b borders) composeBorders(w *rtfWriter, prefix string, colorTable *ColorTable) {
+	for i, side := range []struct {
+		name string
+		on   bool
+	}{
+		{"l", b.borderLeft},
+		{"r", b.borderRight},
+		{"t", b.borderTop},
+		{"b", b.borderBottom},
+	} {
+		if side.on {
+			composeBorderLine(w, prefix+side.name, b.getBorderLine(i), colorTable)
+		}
+	}
+}
+
+// composeBorderLine writes border definition started with control word cw
+func composeBorderLine(w *rtfWriter, cw string, line borderLine, colorTable *ColorTable) {
+	w.printf("\n\\%s\\brdrw%d\\brdr%s", cw, line.width, line.style)
+	for c := range *colorTable {
+		if ((*colorTable)[c]).name == line.color {
+			w.printf("\\brdrcf%d", c+1)
+		}
 	}
-	res.WriteString("\\cell")
-	return res.String()
 }
 
 func (dc TableCell) getCellWidth() int {
@@ -524,41 +1004,36 @@ func (dc *TableCell) SetBorderWidth(value int) *TableCell {
 	return dc
 }
 
+// SetBorderSide sets style, width and color of the cell border side (BorderSideLeft, BorderSideRight, BorderSideTop, BorderSideBottom)
+func (dc *TableCell) SetBorderSide(side string, style string, width int, color string) *TableCell {
+	i := getSideIndex(side)
+	if i < 0 || i >= sideInsideH || !isBorderStyle(style) {
+		return dc
+	}
+	dc.setSide(i, borderLine{style: style, width: width, color: color})
+	return dc
+}
+
+// SetDiagonalDown sets diagonal border from the top left to the bottom right corner of the cell
+func (dc *TableCell) SetDiagonalDown(style string, width int, color string) *TableCell {
+	if isBorderStyle(style) {
+		dc.diagonalDown = borderLine{style: style, width: width, color: color}
+	}
+	return dc
+}
+
+// SetDiagonalUp sets diagonal border from the bottom left to the top right corner of the cell
+func (dc *TableCell) SetDiagonalUp(style string, width int, color string) *TableCell {
+	if isBorderStyle(style) {
+		dc.diagonalUp = borderLine{style: style, width: width, color: color}
+	}
+	return dc
+}
+
 // SetBorderStyle function sets cell's border style
 func (dc *TableCell) SetBorderStyle(bStyle string) *TableCell {
-	bStyle = BorderSingleThickness
-	for _, i := range []string{
-		BorderDashSmall,
-		BorderDashed,
-		BorderDotDash,
-		BorderDotDotDash,
-		BorderDotted,
-		BorderDouble,
-		BorderDoubleThickness,
-		BorderWavyDouble,
-		BorderEmboss,
-		BorderEngrave,
-		BorderHairline,
-		BorderInset,
-		BorderOutset,
-		BorderShadowed,
-		BorderSingleThickness,
-		BorderStripped,
-		BorderThickThinLarge,
-		BorderThickThinMedium,
-		BorderThickThinSmall,
-		BorderThinThickLarge,
-		BorderThinThickMedium,
-		BorderThinThickSmall,
-		BorderThinThickThinLarge,
-		BorderThinThickThinMedium,
-		BorderTriple,
-		BorderWavy,
-	} {
-		if bStyle == i {
-			dc.borderStyle = i
-			break
-		}
+	if isBorderStyle(bStyle) {
+		dc.borderStyle = bStyle
 	}
 	return dc
 }
@@ -589,6 +1064,18 @@ func (dc *TableCell) SetVerticalMergedNext() *TableCell {
 	return dc
 }
 
+// SetHorizontalMergedFirst sets this cell to be first in horizontal merging.
+func (dc *TableCell) SetHorizontalMergedFirst() *TableCell {
+	dc.horizontalMerged = "gf"
+	return dc
+}
+
+// SetHorizontalMergedNext sets this cell to be not first cell in horizontal merging.
+func (dc *TableCell) SetHorizontalMergedNext() *TableCell {
+	dc.horizontalMerged = "rg"
+	return dc
+}
+
 // func (dc TableCell) getVerticalMergedProperty() string {
 // 	return dc.verticalMerged
 // }
@@ -657,6 +1144,50 @@ func (dc *TableCell) SetVAlign(valign string) *TableCell {
 	return dc
 }
 
+// SetAlign sets default aligning (AlignLeft, AlignCenter, etc.) of the cell paragraphs, including already added ones.
+// Paragraphs with explicitly set aligning keep it.
+func (dc *TableCell) SetAlign(align string) *TableCell {
+	for _, i := range []string{AlignCenter, AlignLeft, AlignRight, AlignJustify, AlignDistribute} {
+		if i == align {
+			dc.align = i
+			for _, c := range dc.content {
+				if p, ok := c.(*Paragraph); ok && !p.alignSet {
+					p.align = i
+				}
+			}
+		}
+	}
+	return dc
+}
+
+// SetTextFlow sets direction of the cell text (TextFlowLeftToRight, TextFlowBottomToTop, etc.)
+func (dc *TableCell) SetTextFlow(flow string) *TableCell {
+	for _, i := range []string{
+		TextFlowLeftToRight,
+		TextFlowTopToBottom,
+		TextFlowBottomToTop,
+		TextFlowLeftToRightVertical,
+		TextFlowTopToBottomVertical,
+	} {
+		if flow == i {
+			dc.textFlow = i
+		}
+	}
+	return dc
+}
+
+// SetNoWrap prevents wrapping of the cell text
+func (dc *TableCell) SetNoWrap(noWrap bool) *TableCell {
+	dc.noWrap = noWrap
+	return dc
+}
+
+// SetFitText shrinks or expands the cell text to fit the cell width
+func (dc *TableCell) SetFitText(fitText bool) *TableCell {
+	dc.fitText = fitText
+	return dc
+}
+
 // SetBorderColor function sets cell's border color
 func (dc *TableCell) SetBorderColor(color string) *TableCell {
 	dc.borderColor = color
@@ -0,0 +1,517 @@
+package rtfdoc
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTableMargins(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(t *Table)
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "default margins are not written",
+			build:   func(t *Table) {},
+			want:    []string{"\\cellx2000"},
+			notWant: []string{"\\trleft", "\\sl-"},
+		},
+		{
+			name:  "left margin is row indent",
+			build: func(t *Table) { t.SetMarginLeft(300) },
+			want:  []string{"\\trleft300\\tblind300\\tblindtype3", "\\cellx2300"},
+		},
+		{
+			name:  "top and bottom margins are space around the table",
+			build: func(t *Table) { t.SetMarginTop(120).SetMarginBottom(240) },
+			want:  []string{"\\pard \\sl-120\\slmult0 \\par\n{\\trowd", "\\row}\n\\pard \\sl-240\\slmult0 \\par"},
+		},
+		{
+			name: "floating table has no space",
+			build: func(t *Table) {
+				t.SetMarginTop(120).SetPosition(0, 0, PositionFromMargin, PositionFromParagraph)
+			},
+			notWant: []string{"\\sl-120"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			table := doc.AddTable()
+			tt.build(table)
+			table.AddTableRow().AddDataCell(2000).AddParagraph().AddText("cell", 12, FontArial, ColorBlack)
+			body := exportBody(doc)
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("%q not found in\n%s", s, body)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(body, s) {
+					t.Errorf("unexpected %q in\n%s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestTableMarginsRoundTrip(t *testing.T) {
+	doc := NewDocument()
+	doc.AddParagraph().AddText("before", 12, FontArial, ColorBlack)
+	for _, margin := range []int{120, 240} {
+		table := doc.AddTable().SetWidth(2000).SetMarginLeft(200).SetMarginTop(margin).SetMarginBottom(margin)
+		table.AddTableRow().AddDataCell(2000).AddParagraph().AddText("cell", 12, FontArial, ColorBlack)
+	}
+	doc.AddParagraph().SetLineSpacing(200, LineSpacingExact)
+	doc.AddParagraph().AddText("after", 12, FontArial, ColorBlack)
+
+	out := doc.Export()
+	parsed, err := Parse(bytes.NewReader(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.content) != len(doc.content) {
+		t.Errorf("got %d items, want %d", len(parsed.content), len(doc.content))
+	}
+	if again := parsed.Export(); !bytes.Equal(out, again) {
+		t.Errorf("export of parsed document differs:\n%s\n---\n%s", out, again)
+	}
+}
+
+func TestSetBorderStyle(t *testing.T) {
+	tests := []struct {
+		style string
+		want  string
+	}{
+		{BorderDouble, BorderDouble},
+		{BorderWavy, BorderWavy},
+		{"unknown", BorderSingleThickness},
+		{"", BorderSingleThickness},
+	}
+	for _, tt := range tests {
+		table := NewDocument().AddTable()
+		tr := table.AddTableRow()
+		dc := tr.AddDataCell(1000)
+		table.SetBorderStyle(tt.style)
+		if table.borderStyle != tt.want || tr.borderStyle != tt.want || dc.borderStyle != tt.want {
+			t.Errorf("%q: got %q, %q, %q, want %q", tt.style, table.borderStyle, tr.borderStyle, dc.borderStyle, tt.want)
+		}
+	}
+}
+
+func TestCellSpans(t *testing.T) {
+	type cell struct {
+		width      int
+		horizontal string
+		vertical   string
+	}
+	tests := []struct {
+		name  string
+		build func(table *Table)
+		want  [][]cell
+	}{
+		{
+			name: "column and row span",
+			build: func(table *Table) {
+				tr := table.AddTableRow()
+				tr.AddDataCell(1000)
+				tr.AddDataCellSpan(2000, 2, 2)
+				table.AddTableRow().AddDataCell(1000)
+			},
+			want: [][]cell{
+				{{1000, "", ""}, {1000, "gf", "gf"}, {1000, "rg", "gf"}},
+				{{1000, "", ""}, {1000, "gf", "rg"}, {1000, "rg", "rg"}},
+			},
+		},
+		{
+			name: "preceding cells of different width",
+			build: func(table *Table) {
+				tr := table.AddTableRow()
+				tr.AddDataCell(1000)
+				tr.AddDataCellSpan(2000, 1, 3)
+				tr.AddDataCell(500)
+				tr = table.AddTableRow()
+				tr.AddDataCell(1500)
+				tr.AddDataCell(500)
+				table.AddTableRow().AddDataCell(700)
+			},
+			want: [][]cell{
+				{{1000, "", ""}, {2000, "", "gf"}, {500, "", ""}},
+				{{1500, "", ""}, {2000, "", "rg"}, {500, "", ""}},
+				{{700, "", ""}, {2000, "", "rg"}},
+			},
+		},
+		{
+			name: "span starting the row",
+			build: func(table *Table) {
+				table.AddTableRow().AddDataCellSpan(1000, 1, 2)
+				table.AddTableRow().AddDataCell(2000)
+			},
+			want: [][]cell{
+				{{1000, "", "gf"}},
+				{{1000, "", "rg"}, {2000, "", ""}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewDocument().AddTable()
+			tt.build(table)
+			if len(table.data) != len(tt.want) {
+				t.Fatalf("got %d rows, want %d", len(table.data), len(tt.want))
+			}
+			for i, tr := range table.data {
+				var got []cell
+				for _, c := range tr.cells {
+					got = append(got, cell{c.cellWidth, c.horizontalMerged, c.verticalMerged})
+				}
+				if !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("row %d: got %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCellSpanBeyondTable(t *testing.T) {
+	doc := NewDocument()
+	table := doc.AddTable()
+	table.AddTableRow().AddDataCellSpan(2000, 2, 3)
+	if body := exportBody(doc); strings.Contains(body, "\\clvmgf") {
+		t.Errorf("cell without following rows is merged vertically:\n%s", body)
+	}
+
+	table.AddTableRow().AddDataCell(1000)
+	if body := exportBody(doc); strings.Count(body, "\\clvmgf") != 2 || strings.Count(body, "\\clvmrg") != 2 {
+		t.Errorf("cell spanned into added row is not merged:\n%s", body)
+	}
+}
+
+func TestRowProperties(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(tr *TableRow)
+		want  string
+	}{
+		{"header", func(tr *TableRow) { tr.SetHeader(true) }, "\\trhdr"},
+		{"keep together", func(tr *TableRow) { tr.SetKeepTogether(true) }, "\\trkeep"},
+		{"cant split", func(tr *TableRow) { tr.SetCantSplit(true) }, "\\trkeep"},
+		{"keep with next", func(tr *TableRow) { tr.SetKeepWithNext(true) }, "\\trkeepfollow"},
+		{"height at least", func(tr *TableRow) { tr.SetHeight(400, RowHeightAtLeast) }, "\\trrh400"},
+		{"exact height", func(tr *TableRow) { tr.SetHeight(400, RowHeightExact) }, "\\trrh-400"},
+		{"all", func(tr *TableRow) {
+			tr.SetHeader(true).SetKeepTogether(true).SetKeepWithNext(true).SetHeight(300, RowHeightExact)
+		}, "\\trhdr\\trkeep\\trkeepfollow\\trrh-300"},
+		{"negative height and unknown rule are ignored", func(tr *TableRow) {
+			tr.SetHeight(-400, RowHeightAtLeast).SetHeight(400, "unknown")
+		}, ""},
+		{"switched off", func(tr *TableRow) {
+			tr.SetHeader(true).SetHeader(false).SetKeepTogether(true).SetKeepTogether(false)
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tr := doc.AddTable().SetWidth(1000).AddTableRow()
+			tt.build(tr)
+			tr.AddDataCell(1000).AddParagraph().AddText("cell", 12, FontArial, ColorBlack)
+			body := exportBody(doc)
+			start := strings.Index(body, "\\trftsWidth3\n") + len("\\trftsWidth3\n")
+			got := body[start : start+strings.Index(body[start:], "\n")]
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// Row properties are kept by the reader
+			parsed, err := Parse(strings.NewReader(string(doc.Export())))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again := exportBody(parsed); again != body {
+				t.Errorf("export of parsed document differs:\n%s\n---\n%s", body, again)
+			}
+		})
+	}
+}
+
+func TestNestedTables(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(dc *TableCell)
+		want  []string
+	}{
+		{
+			name: "nested table follows cell paragraph",
+			build: func(dc *TableCell) {
+				dc.AddParagraph().AddText("outer", 12, FontArial, ColorBlack)
+				dc.AddTable().SetWidth(1000).AddTableRow().AddDataCell(1000).AddParagraph().AddText("inner", 12, FontArial, ColorBlack)
+			},
+			want: []string{
+				"outer}}\\par\n",
+				"\\intbl\\itap2 {\n{\\f2\\fs24\\cf1 inner}}\n\\nestcell\n{\\*\\nesttableprops\\trowd",
+				"\\cellx1000\n\\nestrow}{\\nonesttables\\par}",
+			},
+		},
+		{
+			name: "cell ends with paragraph after nested table",
+			build: func(dc *TableCell) {
+				dc.AddTable().AddTableRow().AddDataCell(1000)
+			},
+			want: []string{"\\nestrow}{\\nonesttables\\par}\n\\pard \\ql \\fi0 \\li0 \\ri0 {\\intbl}\n\\cell\n\\trowd"},
+		},
+		{
+			name: "third level",
+			build: func(dc *TableCell) {
+				dc.AddTable().AddTableRow().AddDataCell(1000).AddTable().AddTableRow().AddDataCell(500).
+					AddParagraph().AddText("deep", 12, FontArial, ColorBlack)
+			},
+			want: []string{"\\intbl\\itap3 {\n{\\f2\\fs24\\cf1 deep}}\n\\nestcell", "\\intbl\\itap2 {}\n\\nestcell"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc.AddTable().SetWidth(3000).AddTableRow().AddDataCell(3000))
+			body := exportBody(doc)
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("%q not found in\n%s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestParseNestedTables(t *testing.T) {
+	doc := NewDocument()
+	dc := doc.AddTable().AddTableRow().AddDataCell(3000)
+	dc.AddParagraph().AddText("outer", 12, FontArial, ColorBlack)
+	tr := dc.AddTable().AddTableRow()
+	tr.AddDataCell(1000).AddParagraph().AddText("inner 1", 12, FontArial, ColorBlack)
+	tr.AddDataCell(1000).AddParagraph().AddText("inner 2", 12, FontArial, ColorBlack)
+
+	parsed, err := Parse(bytes.NewReader(doc.Export()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nested tables are flattened into paragraphs of the outer cell
+	var got []string
+	for _, c := range parsed.content[0].(*Table).data[0].cells[0].content {
+		got = append(got, paragraphText(c.(*Paragraph)))
+	}
+	if want := []string{"outer", "inner 1", "inner 2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestNestedTableWidth(t *testing.T) {
+	dc := NewDocument().AddTable().AddTableRow().AddDataCell(3000)
+	if got, want := dc.AddTable().maxWidth, 3000-200; got != want {
+		t.Errorf("got maximum width %d, want %d", got, want)
+	}
+}
+
+func TestResolveBorders(t *testing.T) {
+	outer := borderLine{style: BorderDouble, width: 30, color: ColorRed}
+	inner := borderLine{style: BorderDotted, width: 10, color: ColorGray}
+	own := borderLine{style: BorderWavy, width: 20, color: ColorBlue}
+	// Lines of cell sides (left, right, top, bottom) in rows
+	type cellLines [4]borderLine
+	tests := []struct {
+		name  string
+		build func(table *Table)
+		want  [2][2]cellLines
+	}{
+		{
+			name: "table outer and inside lines",
+			build: func(table *Table) {
+				for _, side := range []string{BorderSideLeft, BorderSideRight, BorderSideTop, BorderSideBottom} {
+					table.SetBorderSide(side, outer.style, outer.width, outer.color)
+				}
+				table.SetBorderSide(BorderSideInsideH, inner.style, inner.width, inner.color).
+					SetBorderSide(BorderSideInsideV, inner.style, inner.width, inner.color)
+			},
+			want: [2][2]cellLines{
+				{{outer, inner, outer, inner}, {inner, outer, outer, inner}},
+				{{outer, inner, inner, outer}, {inner, outer, inner, outer}},
+			},
+		},
+		{
+			name: "row lines take precedence over table lines",
+			build: func(table *Table) {
+				table.SetBorderSide(BorderSideInsideH, inner.style, inner.width, inner.color).
+					SetBorderSide(BorderSideInsideV, inner.style, inner.width, inner.color).
+					SetBorderSide(BorderSideLeft, outer.style, outer.width, outer.color)
+				table.data[1].SetBorderSide(BorderSideTop, own.style, own.width, own.color).
+					SetBorderSide(BorderSideInsideV, own.style, own.width, own.color)
+			},
+			want: [2][2]cellLines{
+				{{outer, inner, {}, inner}, {inner, {}, {}, inner}},
+				{{outer, own, own, {}}, {own, {}, own, {}}},
+			},
+		},
+		{
+			name: "cell lines take precedence over row and table lines",
+			build: func(table *Table) {
+				table.SetBorderSide(BorderSideInsideV, inner.style, inner.width, inner.color)
+				table.data[0].SetBorderSide(BorderSideTop, outer.style, outer.width, outer.color)
+				table.data[0].cells[0].SetBorderSide(BorderSideRight, own.style, own.width, own.color).
+					SetBorderSide(BorderSideTop, own.style, own.width, own.color)
+			},
+			want: [2][2]cellLines{
+				{{{}, own, own, {}}, {inner, {}, outer, {}}},
+				{{{}, inner, {}, {}}, {inner, {}, {}, {}}},
+			},
+		},
+		{
+			name: "invalid sides and styles are ignored",
+			build: func(table *Table) {
+				table.SetBorderSide("unknown", outer.style, outer.width, outer.color).
+					SetBorderSide(BorderSideLeft, "unknown", outer.width, outer.color)
+				table.data[0].cells[0].SetBorderSide(BorderSideInsideV, own.style, own.width, own.color)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewDocument().AddTable()
+			for i := 0; i < 2; i++ {
+				tr := table.AddTableRow()
+				tr.AddDataCell(1000)
+				tr.AddDataCell(1000)
+			}
+			tt.build(table)
+			for i, tr := range table.data {
+				row := table.resolveBorders(tr, i == 0, i == len(table.data)-1)
+				for j, c := range row.cells {
+					got := cellLines{c.sides[sideLeft], c.sides[sideRight], c.sides[sideTop], c.sides[sideBottom]}
+					if got != tt.want[i][j] {
+						t.Errorf("cell %d:%d: got %+v, want %+v", i, j, got, tt.want[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCellDiagonals(t *testing.T) {
+	doc := NewDocument()
+	doc.AddTable().AddTableRow().AddDataCell(1000).
+		SetDiagonalDown(BorderDouble, 10, ColorRed).
+		SetDiagonalUp(BorderDotted, 20, ColorBlue).
+		SetDiagonalUp("unknown", 30, ColorBlack)
+	body := exportBody(doc)
+	for _, want := range []string{"\\cldglu\\brdrw10\\brdrdb\\brdrcf7", "\\cldgll\\brdrw20\\brdrdot\\brdrcf2"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("%q not found in\n%s", want, body)
+		}
+	}
+}
+
+func TestCellTextProperties(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(dc *TableCell)
+		want  string
+	}{
+		{"default", func(dc *TableCell) {}, "\\clvertal\\cellx1000"},
+		{"vertical align", func(dc *TableCell) { dc.SetVAlign(VAlignBottom) }, "\\clvertalb\\cellx1000"},
+		{"unknown vertical align is top", func(dc *TableCell) { dc.SetVAlign("unknown") }, "\\clvertalt\\cellx1000"},
+		{"text flow", func(dc *TableCell) { dc.SetTextFlow(TextFlowBottomToTop) }, "\\clvertal\\cltxbtlr\\cellx1000"},
+		{"unknown text flow is ignored", func(dc *TableCell) {
+			dc.SetTextFlow(TextFlowTopToBottom).SetTextFlow("unknown")
+		}, "\\clvertal\\cltxtbrl\\cellx1000"},
+		{"no wrap and fit text", func(dc *TableCell) {
+			dc.SetNoWrap(true).SetFitText(true)
+		}, "\\clvertal\\clNoWrap\\clFitText\\cellx1000"},
+		{"switched off", func(dc *TableCell) {
+			dc.SetNoWrap(true).SetNoWrap(false).SetFitText(true).SetFitText(false)
+		}, "\\clvertal\\cellx1000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc.AddTable().SetWidth(1000).AddTableRow().AddDataCell(1000))
+			body := exportBody(doc)
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+
+			// Cell properties are kept by the reader
+			parsed, err := Parse(strings.NewReader(string(doc.Export())))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again := exportBody(parsed); again != body {
+				t.Errorf("export of parsed document differs:\n%s\n---\n%s", body, again)
+			}
+		})
+	}
+}
+
+func TestCellAlign(t *testing.T) {
+	dc := NewDocument().AddTable().AddTableRow().AddDataCell(1000)
+	before := dc.AddParagraph()
+	explicit := dc.AddParagraph().SetAlign(AlignRight)
+	dc.SetAlign(AlignCenter).SetAlign("unknown")
+	after := dc.AddParagraph()
+	for _, tt := range []struct {
+		name string
+		p    *Paragraph
+		want string
+	}{
+		{"paragraph added before", before, AlignCenter},
+		{"paragraph with own aligning", explicit, AlignRight},
+		{"paragraph added after", after, AlignCenter},
+	} {
+		if tt.p.align != tt.want {
+			t.Errorf("%s: got align %q, want %q", tt.name, tt.p.align, tt.want)
+		}
+	}
+}
+
+func TestTableLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(t *Table)
+		want  string
+	}{
+		{"width", func(t *Table) {}, "\\trwWidth1000\\trftsWidth3"},
+		{"cell gap", func(t *Table) { t.SetCellGap(50).SetCellGap(-1) }, "\\trgaph50\\trwWidth1000\\trftsWidth3"},
+		{"floating", func(t *Table) {
+			t.SetPosition(100, 200, PositionFromMargin, PositionFromParagraph)
+		}, "\\trwWidth1000\\trftsWidth3\\tphmrg\\tpvpara\\tposx100\\tposy200\\tdfrmtxtLeft0\\tdfrmtxtRight0\\tdfrmtxtTop0\\tdfrmtxtBottom0"},
+		{"text distance and overlap", func(t *Table) {
+			t.SetPosition(0, 0, PositionFromColumn, PositionFromPage).SetTextDistance(10, 20, 30, 40).SetOverlap(false)
+		}, "\\tphcol\\tpvpg\\tposx0\\tposy0\\tdfrmtxtLeft10\\tdfrmtxtRight20\\tdfrmtxtTop30\\tdfrmtxtBottom40\\tabsnoovrlp"},
+		{"invalid position is ignored", func(t *Table) {
+			t.SetPosition(100, 200, PositionFromParagraph, PositionFromPage).
+				SetPosition(100, 200, PositionFromPage, PositionFromColumn)
+		}, "\\trwWidth1000\\trftsWidth3\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			table := doc.AddTable().SetWidth(1000)
+			tt.build(table)
+			table.AddTableRow().AddDataCell(1000)
+			body := exportBody(doc)
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+
+			// Layout is kept by the reader
+			parsed, err := Parse(strings.NewReader(string(doc.Export())))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again := exportBody(parsed); again != body {
+				t.Errorf("export of parsed document differs:\n%s\n---\n%s", body, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package rtfdoc
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TableOptions defines appearance of the Table built from data
+type TableOptions struct {
+	Header      bool      // first record is a header (for structs header is made of field titles)
+	Widths      []float64 // column width ratios, equal columns by default (struct tags take precedence)
+	Align       []string  // column aligning (AlignLeft, AlignCenter, AlignRight), left by default (struct tags take precedence)
+	FontSize    int       // 12 by default
+	Font        string    // FontTimesNewRoman by default
+	Color       string    // ColorBlack by default
+	HeaderColor string    // background color of the header row
+	StripeColor string    // background color of every second data row (zebra striping)
+}
+
+type tableColumn struct {
+	title  string
+	width  float64
+	align  string
+	format string
+}
+
+// AddTableFromRecords returns Table filled with records, each record is a table row
+func (doc *Document) AddTableFromRecords(records [][]string, opts TableOptions) *Table {
+	columnCount := 0
+	for _, r := range records {
+		if len(r) > columnCount {
+			columnCount = len(r)
+		}
+	}
+	columns := make([]tableColumn, columnCount)
+	t := doc.AddTable()
+	t.fillData(columns, records, opts)
+	return t
+}
+
+// AddTableFromCSV returns Table filled with CSV records read from r
+func (doc *Document) AddTableFromCSV(r io.Reader, opts TableOptions) (*Table, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	return doc.AddTableFromRecords(records, opts), nil
+}
+
+// AddTableFromStructs returns Table filled with slice of structs (or pointers to structs), each struct is a table row.
+// Columns are described by field tags `rtf:"Title,width=2,align=r,format=%.2f"`, fields tagged `rtf:"-"` and unexported fields are skipped.
+// Widths and Align of opts apply to columns, which tags don't define width or aligning for.
+func (doc *Document) AddTableFromStructs(slice interface{}, opts TableOptions) (*Table, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, errors.New("slice of structs expected")
+	}
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, errors.New("slice of structs expected")
+	}
+
+	var columns []tableColumn
+	var fields []int
+	for i := 0; i < elemType.NumField(); i++ {
+		f := elemType.Field(i)
+		tag := f.Tag.Get("rtf")
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		columns = append(columns, parseColumnTag(f.Name, tag))
+		fields = append(fields, i)
+	}
+
+	var records [][]string
+	if opts.Header {
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = c.title
+		}
+		records = append(records, header)
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		record := make([]string, len(fields))
+		if elem.IsValid() {
+			for j, f := range fields {
+				record[j] = fmt.Sprintf(columns[j].format, elem.Field(f).Interface())
+			}
+		}
+		records = append(records, record)
+	}
+
+	t := doc.AddTable()
+	t.fillData(columns, records, opts)
+	return t, nil
+}
+
+// parseColumnTag returns column described by rtf tag, invalid options are ignored
+func parseColumnTag(name string, tag string) tableColumn {
+	c := tableColumn{title: name, format: "%v"}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		c.title = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "width":
+			if w, err := strconv.ParseFloat(kv[1], 64); err == nil && w > 0 {
+				c.width = w
+			}
+		case "align":
+			c.align = kv[1]
+		case "format":
+			c.format = kv[1]
+		}
+	}
+	return c
+}
+
+// fillData adds rows of records to the Table
+func (t *Table) fillData(columns []tableColumn, records [][]string, opts TableOptions) {
+	if len(columns) == 0 {
+		return
+	}
+	// Options fill column settings, which are not defined by struct tags
+	for i := range columns {
+		c := &columns[i]
+		if c.width == 0 {
+			c.width = 1
+			if i < len(opts.Widths) && opts.Widths[i] > 0 {
+				c.width = opts.Widths[i]
+			}
+		}
+		if c.align == "" && i < len(opts.Align) {
+			c.align = opts.Align[i]
+		}
+	}
+	fontSize := opts.FontSize
+	if fontSize == 0 {
+		fontSize = defaultFontSize / 2
+	}
+	font := opts.Font
+	if font == "" {
+		font = FontTimesNewRoman
+	}
+	color := opts.Color
+	if color == "" {
+		color = ColorBlack
+	}
+
+	if t.width == 0 {
+		t.SetWidth(t.maxWidth)
+	}
+	ratio := make([]float64, len(columns))
+	for i, c := range columns {
+		ratio[i] = c.width
+	}
+	widths := t.GetTableCellWidthByRatio(ratio...)
+
+	for i, record := range records {
+		isHeader := opts.Header && i == 0
+		tr := t.AddTableRow().SetHeader(isHeader)
+		background := ""
+		switch {
+		case isHeader:
+			background = opts.HeaderColor
+		case opts.StripeColor != "":
+			dataRow := i
+			if !opts.Header {
+				dataRow++
+			}
+			if dataRow%2 == 0 {
+				background = opts.StripeColor
+			}
+		}
+		for j, c := range columns {
+			dc := tr.AddDataCell(widths[j])
+			if background != "" {
+				dc.SetBackgroundColor(background)
+			}
+			p := dc.AddParagraph()
+			if c.align != "" {
+				p.SetAlign(c.align)
+			}
+			if j < len(record) {
+				txt := p.AddText(record[j], fontSize, font, color)
+				if isHeader {
+					txt.SetBold()
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package rtfdoc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// tableTexts returns text of the first paragraph of each Table cell
+func tableTexts(t *Table) [][]string {
+	var res [][]string
+	for _, tr := range t.data {
+		var row []string
+		for _, dc := range tr.cells {
+			row = append(row, paragraphText(dc.content[0].(*Paragraph)))
+		}
+		res = append(res, row)
+	}
+	return res
+}
+
+func TestParseColumnTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want tableColumn
+	}{
+		{"", tableColumn{title: "Field", format: "%v"}},
+		{"Title", tableColumn{title: "Title", format: "%v"}},
+		{",width=2", tableColumn{title: "Field", width: 2, format: "%v"}},
+		{"Price,width=1.5,align=r,format=%.2f", tableColumn{title: "Price", width: 1.5, align: "r", format: "%.2f"}},
+		{"Price, align=c", tableColumn{title: "Price", align: "c", format: "%v"}},
+		{"Price,width=-1,width=x,unknown=1,novalue", tableColumn{title: "Price", format: "%v"}},
+		{"Time,format=15:04=x", tableColumn{title: "Time", format: "15:04=x"}},
+	}
+	for _, tt := range tests {
+		if got := parseColumnTag("Field", tt.tag); got != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestAddTableFromRecords(t *testing.T) {
+	tests := []struct {
+		name       string
+		records    [][]string
+		opts       TableOptions
+		want       [][]string
+		widths     []float64 // column shares of the Table width
+		header     []bool
+		background []string
+	}{
+		{
+			name:       "equal columns",
+			records:    [][]string{{"a", "b"}, {"c", "d"}},
+			want:       [][]string{{"a", "b"}, {"c", "d"}},
+			widths:     []float64{0.5, 0.5},
+			header:     []bool{false, false},
+			background: []string{"", ""},
+		},
+		{
+			name:       "short record gets empty cells",
+			records:    [][]string{{"a", "b", "c"}, {"d"}},
+			want:       [][]string{{"a", "b", "c"}, {"d", "", ""}},
+			header:     []bool{false, false},
+			background: []string{"", ""},
+		},
+		{
+			name:       "header and stripes",
+			records:    [][]string{{"h"}, {"1"}, {"2"}, {"3"}},
+			opts:       TableOptions{Header: true, HeaderColor: ColorSilver, StripeColor: ColorGray},
+			want:       [][]string{{"h"}, {"1"}, {"2"}, {"3"}},
+			header:     []bool{true, false, false, false},
+			background: []string{ColorSilver, "", ColorGray, ""},
+		},
+		{
+			name:       "stripes without header",
+			records:    [][]string{{"1"}, {"2"}, {"3"}},
+			opts:       TableOptions{StripeColor: ColorGray},
+			want:       [][]string{{"1"}, {"2"}, {"3"}},
+			header:     []bool{false, false, false},
+			background: []string{"", ColorGray, ""},
+		},
+		{
+			name:       "width ratios",
+			records:    [][]string{{"a", "b"}},
+			opts:       TableOptions{Widths: []float64{1, 3}},
+			want:       [][]string{{"a", "b"}},
+			widths:     []float64{0.25, 0.75},
+			header:     []bool{false},
+			background: []string{""},
+		},
+		{
+			name:    "no records",
+			records: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			table := doc.AddTableFromRecords(tt.records, tt.opts)
+			if got := tableTexts(table); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			for i, tr := range table.data {
+				if tr.isHeader != tt.header[i] {
+					t.Errorf("row %d: got header %v, want %v", i, tr.isHeader, tt.header[i])
+				}
+				for j, dc := range tr.cells {
+					if dc.backgroundColor != tt.background[i] {
+						t.Errorf("row %d: got background %q, want %q", i, dc.backgroundColor, tt.background[i])
+					}
+					if tt.widths == nil {
+						continue
+					}
+					if want := int(tt.widths[j] * float64(table.width)); dc.cellWidth != want {
+						t.Errorf("cell %d: got width %d, want %d", j, dc.cellWidth, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAddTableFromRecordsFormatting(t *testing.T) {
+	doc := NewDocument()
+	table := doc.AddTableFromRecords([][]string{{"h"}, {"d"}}, TableOptions{
+		Header:   true,
+		Align:    []string{AlignRight},
+		FontSize: 10,
+		Font:     FontArial,
+		Color:    ColorBlue,
+	})
+	for i, tr := range table.data {
+		p := tr.cells[0].content[0].(*Paragraph)
+		text := p.content[0].(*Text)
+		if p.align != AlignRight || text.fontSize != 10 || text.fontCode != 2 || text.colorCode != 2 || text.isBold != (i == 0) {
+			t.Errorf("row %d: got align %q, size %d, font %d, color %d, bold %v", i, p.align, text.fontSize, text.fontCode, text.colorCode, text.isBold)
+		}
+	}
+}
+
+func TestAddTableFromCSV(t *testing.T) {
+	doc := NewDocument()
+	table, err := doc.AddTableFromCSV(strings.NewReader("a,\"b,c\"\nd\n"), TableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := tableTexts(table), [][]string{{"a", "b,c"}, {"d", ""}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := doc.AddTableFromCSV(strings.NewReader("a,\"b\n"), TableOptions{}); err == nil {
+		t.Error("error expected for invalid CSV")
+	}
+}
+
+func TestAddTableFromStructs(t *testing.T) {
+	type item struct {
+		Name    string
+		Price   float64 `rtf:"Cost,width=3,align=r,format=%.2f"`
+		Skipped int     `rtf:"-"`
+		hidden  int
+	}
+	tests := []struct {
+		name  string
+		slice interface{}
+		opts  TableOptions
+		want  [][]string
+	}{
+		{
+			name:  "structs with header",
+			slice: []item{{Name: "tea", Price: 1.5}, {Name: "milk", Price: 2}},
+			opts:  TableOptions{Header: true},
+			want:  [][]string{{"Name", "Cost"}, {"tea", "1.50"}, {"milk", "2.00"}},
+		},
+		{
+			name:  "pointers",
+			slice: []*item{{Name: "tea", Price: 1.5}, nil},
+			want:  [][]string{{"tea", "1.50"}, {"", ""}},
+		},
+		{
+			name:  "array",
+			slice: [1]item{{Name: "tea"}},
+			want:  [][]string{{"tea", "0.00"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := NewDocument().AddTableFromStructs(tt.slice, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := tableTexts(table); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			tr := table.data[0]
+			if tr.cells[1].cellWidth != 3*tr.cells[0].cellWidth {
+				t.Errorf("got widths %d and %d, want 1:3", tr.cells[0].cellWidth, tr.cells[1].cellWidth)
+			}
+			if align := tr.cells[1].content[0].(*Paragraph).align; align != AlignRight {
+				t.Errorf("got align %q, want %q", align, AlignRight)
+			}
+		})
+	}
+
+	for _, slice := range []interface{}{item{}, []int{1}, nil} {
+		if _, err := NewDocument().AddTableFromStructs(slice, TableOptions{}); err == nil {
+			t.Errorf("%T: error expected", slice)
+		}
+	}
+}
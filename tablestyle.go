@@ -0,0 +1,161 @@
+package rtfdoc
+
+// Built-in table styles
+var (
+	// TableStyleGrid has single borders around all cells and bold header
+	TableStyleGrid = TableStyle{
+		HeaderBold:  true,
+		OuterBorder: TableBorder{Style: BorderSingleThickness, Width: 15, Color: ColorBlack},
+		InnerBorder: TableBorder{Style: BorderSingleThickness, Width: 15, Color: ColorBlack},
+		Padding:     60,
+	}
+	// TableStyleStriped has shaded header, banded rows and thin gray inner borders
+	TableStyleStriped = TableStyle{
+		HeaderBackground: ColorSilver,
+		HeaderBold:       true,
+		BandBackground:   ColorSilver,
+		OuterBorder:      TableBorder{Style: BorderSingleThickness, Width: 15, Color: ColorBlack},
+		InnerBorder:      TableBorder{Style: BorderSingleThickness, Width: 5, Color: ColorGray},
+		Padding:          60,
+	}
+	// TableStyleReport has dark header with white text, emphasized first column and no inner borders
+	TableStyleReport = TableStyle{
+		HeaderBackground: ColorNavy,
+		HeaderFontColor:  ColorWhite,
+		HeaderBold:       true,
+		FirstColumnBold:  true,
+		OuterBorder:      TableBorder{Style: BorderDouble, Width: 15, Color: ColorNavy},
+		Padding:          60,
+		VAlign:           VAlignMiddle,
+	}
+)
+
+// ApplyStyle sets style of the Table. Style is resolved when the Table is written,
+// so rows and cells added later are styled too.
+func (t *Table) ApplyStyle(style TableStyle) *Table {
+	t.style = &style
+	return t
+}
+
+// styleRows returns copies of the Table rows with applied style
+func (ts TableStyle) styleRows(rows []*TableRow) []*TableRow {
+	res := make([]*TableRow, len(rows))
+	body := 0
+	for i, tr := range rows {
+		r := *tr
+		// Row setters change cells too, so fields are set directly
+		on := ts.OuterBorder.Style != ""
+		r.borderLeft, r.borderRight, r.borderTop, r.borderBottom = on, on, on, on
+		if on {
+			r.borderStyle, r.borderWidth, r.borderColor = ts.OuterBorder.Style, ts.OuterBorder.Width, ts.OuterBorder.Color
+		}
+		r.cells = make([]*TableCell, len(tr.cells))
+		for j, tc := range tr.cells {
+			c := *tc.merged()
+			ts.styleBorders(&c, tr, i == 0, i == len(rows)-1, j == 0, j == len(tr.cells)-1)
+			if ts.Padding > 0 {
+				c.SetPadding(ts.Padding)
+			}
+			if ts.VAlign != "" {
+				c.SetVAlign(ts.VAlign)
+			}
+
+			background, fontColor, bold := "", "", false
+			switch {
+			case tr.isHeader:
+				background, fontColor, bold = ts.HeaderBackground, ts.HeaderFontColor, ts.HeaderBold
+			case j == 0 && (ts.FirstColumnBackground != "" || ts.FirstColumnBold):
+				background, bold = ts.FirstColumnBackground, ts.FirstColumnBold
+			}
+			if background == "" && !tr.isHeader && body%2 == 1 {
+				background = ts.BandBackground
+			}
+			if c.backgroundColor == "" {
+				c.backgroundColor = background
+			}
+			if fontColor != "" || bold {
+				c.content = styleContent(c.content, fontColor, bold)
+			}
+			r.cells[j] = &c
+		}
+		if !tr.isHeader {
+			body++
+		}
+		res[i] = &r
+	}
+	return res
+}
+
+// styleBorders sets outer or inner border to sides of the cell, which have no line of their own or of the row
+func (ts TableStyle) styleBorders(dc *TableCell, tr *TableRow, isTop, isBottom, isLeft, isRight bool) {
+	rowLines := [4]borderLine{tr.sides[sideInsideV], tr.sides[sideInsideV], tr.sides[sideInsideH], tr.sides[sideInsideH]}
+	for i, outer := range [4]bool{isLeft, isRight, isTop, isBottom} {
+		if outer || tr.sides[i].style != "" {
+			rowLines[i] = tr.sides[i]
+		}
+	}
+	for side, outer := range [4]bool{isLeft, isRight, isTop, isBottom} {
+		if dc.sides[side].style != "" || rowLines[side].style != "" {
+			continue
+		}
+		b := ts.InnerBorder
+		if outer {
+			b = ts.OuterBorder
+		}
+		on := b.Style != ""
+		switch side {
+		case sideLeft:
+			dc.borderLeft = on
+		case sideRight:
+			dc.borderRight = on
+		case sideTop:
+			dc.borderTop = on
+		case sideBottom:
+			dc.borderBottom = on
+		}
+		dc.sides[side] = borderLine{style: b.Style, width: b.Width, color: b.Color}
+	}
+}
+
+// styleContent returns copy of the cell content with changed text color and emphasis
+func styleContent(content []documentItem, fontColor string, bold bool) []documentItem {
+	res := make([]documentItem, len(content))
+	for i, c := range content {
+		p, ok := c.(*Paragraph)
+		if !ok {
+			res[i] = c
+			continue
+		}
+		par := *p
+		par.content = make([]documentItem, len(p.content))
+		for j, item := range p.content {
+			switch v := item.(type) {
+			case *Text:
+				txt := *v
+				styleText(&txt, fontColor, bold)
+				par.content[j] = &txt
+			case *Field:
+				f := *v
+				styleText(&f.Text, fontColor, bold)
+				par.content[j] = &f
+			case *specialChar:
+				sc := *v
+				styleText(&sc.Text, fontColor, bold)
+				par.content[j] = &sc
+			default:
+				par.content[j] = item
+			}
+		}
+		res[i] = &par
+	}
+	return res
+}
+
+func styleText(text *Text, fontColor string, bold bool) {
+	if fontColor != "" {
+		text.SetColor(fontColor)
+	}
+	if bold {
+		text.SetBold()
+	}
+}
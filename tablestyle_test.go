@@ -0,0 +1,183 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableStyleBorders(t *testing.T) {
+	outer := borderLine{style: BorderSingleThickness, width: 15, color: ColorBlack}
+	inner := borderLine{style: BorderSingleThickness, width: 5, color: ColorGray}
+	own := borderLine{style: BorderDouble, width: 30, color: ColorRed}
+	tests := []struct {
+		name  string
+		build func(tr *TableRow)
+		// want holds lines of left, right, top and bottom sides of the cells
+		want [3][4]borderLine
+	}{
+		{
+			name:  "style lines",
+			build: func(tr *TableRow) {},
+			want: [3][4]borderLine{
+				{outer, inner, outer, outer},
+				{inner, inner, outer, outer},
+				{inner, outer, outer, outer},
+			},
+		},
+		{
+			name: "cell line is kept",
+			build: func(tr *TableRow) {
+				tr.cells[1].SetBorderSide(BorderSideLeft, own.style, own.width, own.color)
+			},
+			want: [3][4]borderLine{
+				{outer, inner, outer, outer},
+				{own, inner, outer, outer},
+				{inner, outer, outer, outer},
+			},
+		},
+		{
+			name: "row lines are kept",
+			build: func(tr *TableRow) {
+				tr.SetBorderSide(BorderSideInsideV, own.style, own.width, own.color).
+					SetBorderSide(BorderSideTop, own.style, own.width, own.color)
+			},
+			want: [3][4]borderLine{
+				{outer, own, own, outer},
+				{own, own, own, outer},
+				{own, outer, own, outer},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewDocument().AddTable().ApplyStyle(TableStyleStriped)
+			tr := table.AddTableRow()
+			for i := 0; i < 3; i++ {
+				tr.AddDataCell(1000)
+			}
+			tt.build(tr)
+			styled := table.style.styleRows(table.data)
+			row := table.resolveBorders(styled[0], true, true)
+			for i, c := range row.cells {
+				for side, want := range tt.want[i] {
+					if got := c.getBorderLine(side); got != want {
+						t.Errorf("cell %d side %d: got %+v, want %+v", i, side, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTableStyleContent(t *testing.T) {
+	doc := NewDocument()
+	table := doc.AddTable().ApplyStyle(TableStyleReport)
+	p := table.AddTableRow().SetHeader(true).AddDataCell(2000).AddParagraph()
+	p.AddText("a", 12, FontArial, ColorBlack)
+	p.AddEmDash()
+	body := exportBody(doc)
+	for _, want := range []string{
+		"{\\f2\\fs24\\cf9\\b a}",
+		"{\\f2\\fs24\\cf9\\b \\emdash }",
+		"\\clpadl60\\clpadr60\\clpadt60\\clpadb60\\clpadfl3\\clpadfr3\\clpadft3\\clpadfb3",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("%q not found in\n%s", want, body)
+		}
+	}
+}
+
+func TestTableStyleRows(t *testing.T) {
+	type cell struct {
+		background string
+		bold       bool
+	}
+	tests := []struct {
+		name  string
+		style TableStyle
+		build func(table *Table)
+		want  [][]cell
+	}{
+		{
+			name:  "header and banded rows",
+			style: TableStyleStriped,
+			want: [][]cell{
+				{{ColorSilver, true}, {ColorSilver, true}},
+				{{"", false}, {"", false}},
+				{{ColorSilver, false}, {ColorSilver, false}},
+				{{"", false}, {"", false}},
+			},
+		},
+		{
+			name:  "first column",
+			style: TableStyleReport,
+			want: [][]cell{
+				{{ColorNavy, true}, {ColorNavy, true}},
+				{{"", true}, {"", false}},
+				{{"", true}, {"", false}},
+				{{"", true}, {"", false}},
+			},
+		},
+		{
+			name:  "first column background takes precedence over band",
+			style: TableStyle{FirstColumnBackground: ColorRed, BandBackground: ColorGray},
+			want: [][]cell{
+				{{"", false}, {"", false}},
+				{{ColorRed, false}, {"", false}},
+				{{ColorRed, false}, {ColorGray, false}},
+				{{ColorRed, false}, {"", false}},
+			},
+		},
+		{
+			name:  "cell background is kept",
+			style: TableStyleStriped,
+			build: func(table *Table) { table.data[2].cells[1].SetBackgroundColor(ColorRed) },
+			want: [][]cell{
+				{{ColorSilver, true}, {ColorSilver, true}},
+				{{"", false}, {"", false}},
+				{{ColorSilver, false}, {ColorRed, false}},
+				{{"", false}, {"", false}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewDocument().AddTable().ApplyStyle(tt.style)
+			// Rows added after the style is applied are styled too
+			for i := 0; i < 4; i++ {
+				tr := table.AddTableRow().SetHeader(i == 0)
+				for j := 0; j < 2; j++ {
+					tr.AddDataCell(1000).AddParagraph().AddText("x", 12, FontArial, ColorBlack)
+				}
+			}
+			if tt.build != nil {
+				tt.build(table)
+			}
+			styled := table.style.styleRows(table.data)
+			for i, tr := range styled {
+				for j, c := range tr.cells {
+					text := c.content[0].(*Paragraph).content[0].(*Text)
+					if got := (cell{c.backgroundColor, text.isBold}); got != tt.want[i][j] {
+						t.Errorf("cell %d:%d: got %+v, want %+v", i, j, got, tt.want[i][j])
+					}
+				}
+			}
+			// Table itself isn't changed
+			if text := table.data[0].cells[0].content[0].(*Paragraph).content[0].(*Text); text.isBold {
+				t.Error("style changed the Table text")
+			}
+		})
+	}
+}
+
+func TestTableStyleCellSettings(t *testing.T) {
+	table := NewDocument().AddTable().ApplyStyle(TableStyleReport)
+	table.AddTableRow().AddDataCell(1000)
+	c := table.style.styleRows(table.data)[0].cells[0]
+	if c.vTextAlign != VAlignMiddle || c.paddingLeft != 60 || c.paddingTop != 60 {
+		t.Errorf("got vertical align %q, padding %d, %d", c.vTextAlign, c.paddingLeft, c.paddingTop)
+	}
+	if c := table.data[0].cells[0]; c.paddingLeft != 0 {
+		t.Errorf("style changed the Table cell padding to %d", c.paddingLeft)
+	}
+}
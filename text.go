@@ -2,12 +2,18 @@ package rtfdoc
 
 import (
 	"fmt"
+	"io"
+	"log"
+	"regexp"
 	"strings"
 )
 
-func (text Text) compose() string {
-	var res strings.Builder
+func (text Text) compose(w *rtfWriter) {
+	text.composeFormatted(w, escapeText(text.content))
+}
 
+// composeFormatted writes already prepared rtf content with Text formatting
+func (text Text) composeFormatted(w *rtfWriter, PreparedText string) {
 	var emphTextSlice []string
 	if text.isBold {
 		emphTextSlice = append(emphTextSlice, "\\b")
@@ -34,63 +40,179 @@ func (text Text) compose() string {
 		emphTextSlice = append(emphTextSlice, "\\horzvert0")
 	}
 
-	PreparedText := convertNonASCIIToUTF16(text.content)
+	if w.profile == OutputProfilePlainText {
+		// forkjura: I want to write only text
+		w.printf("\n\\fs%d{%s}", text.fontSize*2, PreparedText)
+		return
+	}
 
-	// If there is no emphasis, remove it from format so that there is no additional space
-	// sb0 and sa0 remove leading and trailing spaces
-	// if len(emphTextSlice) > 0 {
-	// 	res.WriteString(fmt.Sprintf("\n\\sb0 \\sa0 \\fs%d\\f%d \\cf%d{%s %s}\\f0", text.fontSize*2, text.fontCode, text.colorCode, strings.Join(emphTextSlice, " "), PreparedText))
-	// } else {
-	// 	res.WriteString(fmt.Sprintf("\n\\sb0 \\sa0 \\fs%d\\f%d \\cf%d{%s}\\f0", text.fontSize*2, text.fontCode, text.colorCode, PreparedText))
-	// }
+	styleStr := ""
+	var charStyle *Style
+	if i, s := text.styleSheet.find(text.style); s != nil {
+		r := s.resolve()
+		charStyle = &r
+		styleStr = fmt.Sprintf("\\cs%d%s", i, r.charProperties())
+	}
+	// Style defines property, if character style or paragraph style sets it
+	defines := func(isSet func(s *Style) bool) bool {
+		return (charStyle != nil && isSet(charStyle)) || (w.parStyle != nil && isSet(w.parStyle))
+	}
+	if text.explicitFont || !defines(func(s *Style) bool { return s.fontCode != "" }) {
+		styleStr += fmt.Sprintf("\\f%d", text.fontCode)
+	}
+	if text.fontSize > 0 && (text.explicitFontSize || !defines(func(s *Style) bool { return s.fontSize > 0 })) {
+		styleStr += fmt.Sprintf("\\fs%d", text.fontSize*2)
+	}
+	if text.explicitColor || !defines(func(s *Style) bool { return s.colorCode != "" }) {
+		styleStr += fmt.Sprintf("\\cf%d", text.colorCode)
+	}
 
-	// forkjura: I want to write only text
-	res.WriteString(fmt.Sprintf("\n\\fs%d{%s}", text.fontSize*2, PreparedText))
+	// Formatting is kept inside the group, so it doesn't affect following text
+	w.printf("\n{%s%s %s}",
+		styleStr,
+		strings.Join(emphTextSlice, ""),
+		PreparedText,
+	)
+}
 
-	return res.String()
+// WriteTo streams Text to w. It implements io.WriterTo interface.
+func (text *Text) WriteTo(w io.Writer) (int64, error) {
+	return writeComposed(w, text.compose)
 }
 
-// AddText returns new text instance
+// AddText returns new text instance. Non-zero font size and known font and color are kept even if the
+// paragraph or character style defines them, zero size and empty font or color take ones of the style.
 func (p *Paragraph) AddText(textStr string, fontSize int, fontCode string, colorCode string) *Text {
 
-	fn := 0
+	fn, fontSet := 0, false
 	for i, f := range *p.generalSettings.fontColor {
 		if f.code == fontCode {
 
-			fn = i
+			fn, fontSet = i, true
 		}
 	}
 
-	fc := 0
+	fc, colorSet := 0, false
 	for i, c := range *p.generalSettings.colorTable {
 		if c.name == colorCode {
 
-			fc = i + 1
+			fc, colorSet = i+1, true
 		}
 	}
 	txt := Text{
-		fontSize:  fontSize,
-		fontCode:  fn,
-		colorCode: fc,
-		content:   textStr,
+		fontSize:         fontSize,
+		fontCode:         fn,
+		colorCode:        fc,
+		explicitFont:     fontSet,
+		explicitFontSize: fontSize > 0,
+		explicitColor:    colorSet,
+		content:          textStr,
 		generalSettings: generalSettings{
 			colorTable: p.colorTable,
 			fontColor:  p.fontColor,
+			styleSheet: p.styleSheet,
+			listTable:  p.listTable,
 		},
 	}
 	p.content = append(p.content, &txt)
 	return &txt
 }
 
-//AddNewLine adds new line into Paragraph text
+// AddNewLine adds new line into Paragraph text
 func (p *Paragraph) AddNewLine() *Paragraph {
-	txt := Text{
-		content: "\\line",
+	p.content = append(p.content, &controlWord{word: "line"})
+	return p
+}
+
+// AddTab adds tab into Paragraph text, it moves text to the next tab stop
+func (p *Paragraph) AddTab() *Paragraph {
+	p.content = append(p.content, &controlWord{word: "tab"})
+	return p
+}
+
+// AddPageBreak adds page break into Paragraph text
+func (p *Paragraph) AddPageBreak() *Paragraph {
+	p.content = append(p.content, &controlWord{word: "page"})
+	return p
+}
+
+// AddColumnBreak adds column break into Paragraph text, following text starts from the next column
+func (p *Paragraph) AddColumnBreak() *Paragraph {
+	p.content = append(p.content, &controlWord{word: "column"})
+	return p
+}
+
+// AddSoftHyphen adds hyphen, which is shown only when the word is split at the line end
+func (p *Paragraph) AddSoftHyphen() *Text {
+	return p.addSpecialChar("\\-")
+}
+
+// AddNonBreakingSpace adds space, which doesn't split the line
+func (p *Paragraph) AddNonBreakingSpace() *Text {
+	return p.addSpecialChar("\\~")
+}
+
+// AddNonBreakingHyphen adds hyphen, which doesn't split the line
+func (p *Paragraph) AddNonBreakingHyphen() *Text {
+	return p.addSpecialChar("\\_")
+}
+
+// AddEmDash adds em dash
+func (p *Paragraph) AddEmDash() *Text {
+	return p.addSpecialChar("\\emdash ")
+}
+
+// AddEnDash adds en dash
+func (p *Paragraph) AddEnDash() *Text {
+	return p.addSpecialChar("\\endash ")
+}
+
+// addSpecialChar adds run of special character, which takes formatting of the preceding text
+func (p *Paragraph) addSpecialChar(rtf string) *Text {
+	sc := specialChar{
+		Text: Text{
+			fontSize: defaultFontSize / 2,
+			generalSettings: generalSettings{
+				colorTable: p.colorTable,
+				fontColor:  p.fontColor,
+				styleSheet: p.styleSheet,
+				listTable:  p.listTable,
+			},
+		},
+		rtf: rtf,
 	}
-	p.content = append(p.content, &txt)
+	for i := len(p.content) - 1; i >= 0; i-- {
+		if t, ok := p.content[i].(*Text); ok {
+			sc.Text = *t
+			sc.content = ""
+			break
+		}
+	}
+	p.content = append(p.content, &sc)
+	return &sc.Text
+}
+
+func (sc specialChar) compose(w *rtfWriter) {
+	sc.composeFormatted(w, sc.rtf)
+}
+
+var controlWordRegexp = regexp.MustCompile(`^[a-zA-Z]{1,32}(-?[0-9]+)?$`)
+
+// AddControlWord adds raw rtf control word (without leading backslash, e.g. "page" or "sb120")
+// into Paragraph content. Unlike text, control word is written as is, so it must be a valid one.
+func (p *Paragraph) AddControlWord(word string) *Paragraph {
+	if !controlWordRegexp.MatchString(word) {
+		log.Println("Incorrect control word")
+		return p
+	}
+	p.content = append(p.content, &controlWord{word: word})
 	return p
 }
 
+func (cw controlWord) compose(w *rtfWriter) {
+	w.printf("\n\\%s ", cw.word)
+}
+
 // SetBold function sets text to Bold
 func (text *Text) SetBold() *Text {
 	text.isBold = true
@@ -149,6 +271,26 @@ func (text *Text) SetColor(colorCode string) *Text {
 		if (*text.colorTable)[i].name == colorCode {
 			// Присваиваем тексту порядковый номер шрифта
 			text.colorCode = i + 1
+			text.explicitColor = true
+		}
+	}
+
+	return text
+}
+
+// SetFontSize sets text font size
+func (text *Text) SetFontSize(fontSize int) *Text {
+	text.fontSize = fontSize
+	text.explicitFontSize = true
+	return text
+}
+
+// SetFont sets text font
+func (text *Text) SetFont(fontCode string) *Text {
+	for i := range *text.fontColor {
+		if (*text.fontColor)[i].code == fontCode {
+			text.fontCode = i
+			text.explicitFont = true
 		}
 	}
 
@@ -0,0 +1,116 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextCompose(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		build   func(text *Text)
+		want    string
+	}{
+		{
+			name:  "plain",
+			build: func(text *Text) {},
+			want:  "{\\f2\\fs24\\cf1 x}",
+		},
+		{
+			name: "emphasis",
+			build: func(text *Text) {
+				text.SetBold().SetItalic().SetScaps().SetStrike().SetSub().SetSuper().SetUnderlining()
+			},
+			want: "{\\f2\\fs24\\cf1\\b\\i\\scaps\\strike\\sub\\super\\ul x}",
+		},
+		{
+			name:  "rotated",
+			build: func(text *Text) { text.SetRotate() },
+			want:  "{\\f2\\fs24\\cf1\\horzvert0 x}",
+		},
+		{
+			name:  "font, size and color setters",
+			build: func(text *Text) { text.SetFont(FontTimesNewRoman).SetFontSize(10).SetColor(ColorRed) },
+			want:  "{\\f0\\fs20\\cf7 x}",
+		},
+		{
+			name:    "full profile",
+			profile: OutputProfileFull,
+			build:   func(text *Text) { text.SetBold() },
+			want:    "{\\f2\\fs24\\cf1\\b x}",
+		},
+		{
+			name:    "plain text profile writes only font size",
+			profile: OutputProfilePlainText,
+			build:   func(text *Text) { text.SetBold().SetColor(ColorRed) },
+			want:    "\\fs24{x}",
+		},
+		{
+			name:    "unknown profile is ignored",
+			profile: "unknown",
+			build:   func(text *Text) { text.SetBold() },
+			want:    "{\\f2\\fs24\\cf1\\b x}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			if tt.profile != "" {
+				doc.SetOutputProfile(tt.profile)
+			}
+			tt.build(doc.AddParagraph().AddText("x", 12, FontArial, ColorBlack))
+			body := exportBody(doc)
+			if !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+			if tt.profile == OutputProfilePlainText && strings.Contains(body, "\\b") {
+				t.Errorf("unexpected formatting in\n%s", body)
+			}
+		})
+	}
+}
+
+func TestBreaksAndSpecialChars(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(doc *Document)
+		want  string
+	}{
+		{"document page break", func(doc *Document) { doc.AddPageBreak() }, "\n\\page "},
+		{"paragraph page break", func(doc *Document) {
+			doc.AddParagraph().AddText("a", 12, FontArial, ColorBlack)
+			doc.content[0].(*Paragraph).AddPageBreak().AddText("b", 12, FontArial, ColorBlack)
+		}, "a}\n\\page \n{\\f2\\fs24\\cf1 b}"},
+		{"column break", func(doc *Document) {
+			doc.AddParagraph().AddColumnBreak()
+		}, "{\n\\column }"},
+		{"new line", func(doc *Document) {
+			doc.AddParagraph().AddNewLine()
+		}, "{\n\\line }"},
+		{"special characters take preceding text formatting", func(doc *Document) {
+			p := doc.AddParagraph()
+			p.AddText("a", 10, FontTimesNewRoman, ColorRed).SetBold()
+			p.AddNonBreakingSpace()
+			p.AddSoftHyphen()
+			p.AddNonBreakingHyphen()
+			p.AddEmDash()
+			p.AddEnDash().SetItalic()
+		}, "{\\f0\\fs20\\cf7\\b \\~}\n{\\f0\\fs20\\cf7\\b \\-}\n{\\f0\\fs20\\cf7\\b \\_}\n{\\f0\\fs20\\cf7\\b \\emdash }\n{\\f0\\fs20\\cf7\\b\\i \\endash }"},
+		{"special character without text", func(doc *Document) {
+			doc.AddParagraph().AddEmDash()
+		}, "{\\f0\\fs24\\cf0 \\emdash }"},
+		{"control word", func(doc *Document) {
+			doc.AddParagraph().AddControlWord("sb120").AddControlWord("bad word").AddControlWord("")
+		}, "{\n\\sb120 }"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewDocument()
+			tt.build(doc)
+			if body := exportBody(doc); !strings.Contains(body, tt.want) {
+				t.Errorf("%q not found in\n%s", tt.want, body)
+			}
+		})
+	}
+}
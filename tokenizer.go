@@ -0,0 +1,178 @@
+package rtfdoc
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+)
+
+type tokenKind int
+
+const (
+	tokenGroupStart tokenKind = iota
+	tokenGroupEnd
+	tokenControlWord
+	tokenControlSymbol
+	tokenText
+)
+
+// maxControlWordLength is maximum length of control word name defined by RTF specification
+const maxControlWordLength = 32
+
+// maxBinaryLength is maximum accepted length of \bin data
+const maxBinaryLength = 256 << 20
+
+// token is a lexical unit of RTF stream
+type token struct {
+	kind     tokenKind
+	word     string // control word name or control symbol
+	param    int
+	hasParam bool
+	data     []byte // text bytes or hex-decoded byte of \'hh symbol
+}
+
+// tokenizer splits RTF stream into groups, control words, control symbols and text
+type tokenizer struct {
+	r *bufio.Reader
+}
+
+func newTokenizer(r io.Reader) *tokenizer {
+	return &tokenizer{r: bufio.NewReader(r)}
+}
+
+// next returns next token from the stream. It returns io.EOF at the end of the stream.
+func (tz *tokenizer) next() (token, error) {
+	for {
+		c, err := tz.r.ReadByte()
+		if err != nil {
+			return token{}, err
+		}
+		switch c {
+		case '{':
+			return token{kind: tokenGroupStart}, nil
+		case '}':
+			return token{kind: tokenGroupEnd}, nil
+		case '\\':
+			return tz.readControl()
+		case '\r', '\n':
+			// Line breaks are not a part of document text
+			continue
+		default:
+			return tz.readText(c)
+		}
+	}
+}
+
+func (tz *tokenizer) readText(first byte) (token, error) {
+	data := []byte{first}
+	for {
+		b, err := tz.r.Peek(1)
+		if err != nil || b[0] == '\\' || b[0] == '{' || b[0] == '}' {
+			return token{kind: tokenText, data: data}, nil
+		}
+		_, _ = tz.r.ReadByte()
+		if b[0] != '\r' && b[0] != '\n' {
+			data = append(data, b[0])
+		}
+	}
+}
+
+func (tz *tokenizer) readControl() (token, error) {
+	c, err := tz.r.ReadByte()
+	if err != nil {
+		return token{}, io.ErrUnexpectedEOF
+	}
+	if !isASCIILetter(c) {
+		if c == '\'' {
+			return tz.readHexSymbol()
+		}
+		if c == '\r' || c == '\n' {
+			// \<newline> is equal to \par
+			return token{kind: tokenControlWord, word: "par"}, nil
+		}
+		return token{kind: tokenControlSymbol, word: string(c)}, nil
+	}
+
+	name := []byte{c}
+	for {
+		c, err = tz.r.ReadByte()
+		if err == io.EOF {
+			return token{kind: tokenControlWord, word: string(name)}, nil
+		}
+		if err != nil {
+			return token{}, err
+		}
+		if !isASCIILetter(c) {
+			break
+		}
+		if len(name) >= maxControlWordLength {
+			return token{}, errors.New("too long control word")
+		}
+		name = append(name, c)
+	}
+
+	tok := token{kind: tokenControlWord, word: string(name)}
+	if c == '-' || isASCIIDigit(c) {
+		num := []byte{c}
+		eof := false
+		for {
+			c, err = tz.r.ReadByte()
+			if err == io.EOF {
+				eof = true
+				break
+			}
+			if err != nil {
+				return token{}, err
+			}
+			if !isASCIIDigit(c) {
+				break
+			}
+			num = append(num, c)
+		}
+		tok.param, err = strconv.Atoi(string(num))
+		if err != nil {
+			return token{}, errors.New("incorrect control word parameter: " + string(num))
+		}
+		tok.hasParam = true
+		if eof {
+			return tok, nil
+		}
+	}
+	// Space is a part of control word, any other delimiter belongs to the stream
+	if c != ' ' {
+		_ = tz.r.UnreadByte()
+	}
+	return tok, nil
+}
+
+func (tz *tokenizer) readHexSymbol() (token, error) {
+	var hex [2]byte
+	if _, err := io.ReadFull(tz.r, hex[:]); err != nil {
+		return token{}, io.ErrUnexpectedEOF
+	}
+	v, err := strconv.ParseUint(string(hex[:]), 16, 8)
+	if err != nil {
+		return token{}, errors.New("incorrect hex symbol: " + string(hex[:]))
+	}
+	return token{kind: tokenControlSymbol, word: "'", data: []byte{byte(v)}}, nil
+}
+
+// copyBinary copies n bytes of binary data following \binN control word to dst
+func (tz *tokenizer) copyBinary(dst io.Writer, n int) error {
+	if n < 0 || n > maxBinaryLength {
+		return errors.New("incorrect binary data length")
+	}
+	if _, err := io.CopyN(dst, tz.r, int64(n)); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
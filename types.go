@@ -6,7 +6,7 @@ import "image/color"
 
 // documentItem composing interface
 type documentItem interface {
-	compose() string
+	compose(w *rtfWriter)
 }
 
 // cellItem cellizing interface
@@ -17,6 +17,8 @@ type cellItem interface {
 type generalSettings struct {
 	fontColor  *FontTable
 	colorTable *ColorTable // Основные цветовые схемы. обращение в документе к ним с помощью управляющих слов \cfN, где N - порядковый номер цветовой схемы.
+	styleSheet *StyleSheet
+	listTable  *ListTable
 }
 
 // Header - document header struct
@@ -31,6 +33,44 @@ type header struct {
 	//RevTBL     string
 }
 
+// Style defines named paragraph or character style of the document stylesheet
+type Style struct {
+	name          string
+	isCharacter   bool
+	basedOn       string
+	next          string
+	align         string
+	outlineLevel  int // 1 - 9, 0 for body text
+	fontCode      string
+	fontSize      int
+	colorCode     string
+	isBold        bool
+	isItalic      bool
+	isUnderlining bool
+	generalSettings
+}
+
+// StyleSheet defines document stylesheet
+type StyleSheet []*Style
+
+// List defines bulleted or numbered multi-level list. List items are paragraphs.
+type List struct {
+	kind     string
+	levels   [listLevelsCount]listLevel
+	content  *[]documentItem // content, which list items are added to
+	maxWidth int
+	generalSettings
+}
+
+type listLevel struct {
+	format string
+	start  int
+	indent int
+}
+
+// ListTable defines document lists
+type ListTable []*List
+
 // Color type for settings
 type colorItem struct {
 	rgbColor color.RGBA
@@ -46,6 +86,61 @@ type Document struct {
 	pagesize   size
 	maxWidth   int
 	content    []documentItem
+	headers    []*HeaderFooter
+	sections   []*Section
+	columnLayout
+	noteSettings
+
+	outputProfile string
+}
+
+// columnLayout defines newspaper-style columns of the page
+type columnLayout struct {
+	columns       int
+	columnSpacing int
+	columnWidths  []int
+	lineBetween   bool
+}
+
+// noteSettings defines numbering and placement of footnotes and endnotes
+type noteSettings struct {
+	footnoteFormat   string
+	footnoteRestart  string
+	endnoteFormat    string
+	endnoteRestart   string
+	endnotePlacement string
+}
+
+// Footnote defines footnote or endnote, which reference mark is placed in the Paragraph
+type Footnote struct {
+	isEndnote    bool
+	markFontSize int
+	maxWidth     int
+	content      []*Paragraph
+	generalSettings
+}
+
+// Section defines document section with its own page settings, headers, footers and content
+type Section struct {
+	orientation string
+	margins
+	pageFormat      string
+	pagesize        size
+	maxWidth        int
+	pageNumberStart int
+	content         []documentItem
+	headers         []*HeaderFooter
+	columnLayout
+	generalSettings
+}
+
+// HeaderFooter defines page header or footer content
+type HeaderFooter struct {
+	isFooter bool
+	kind     string
+	maxWidth int
+	content  []documentItem
+	generalSettings
 }
 
 // ColorTable defines color table
@@ -71,11 +166,29 @@ type size struct {
 
 // Table is a struct for Table.
 type Table struct {
-	width    int
-	align    string
-	docWidth int
-	maxWidth int
-	data     []*TableRow
+	width      int
+	align      string
+	docWidth   int
+	maxWidth   int
+	data       []*TableRow
+	spans      []*cellSpan // cells spanned into rows, which are not added yet
+	autoFit    bool
+	cellGap    int
+	isFloating bool
+	positionX  int
+	positionY  int
+	// positionFromX and positionFromY are reference frames of the floating Table position
+	positionFromX string
+	positionFromY string
+	noOverlap     bool
+	textDistance  margins // distances of the floating Table from the surrounding text
+	style         *TableStyle
+	nestLevel     int // 1 for the top level Table
+	columnLimits  []columnLimit
+	// Default margins only limit the Table width, margins set by the user are written as indent and spacing
+	explicitMarginLeft   bool
+	explicitMarginTop    bool
+	explicitMarginBottom bool
 	margins
 	paddings
 	borders
@@ -83,14 +196,51 @@ type Table struct {
 	defaultFontSize int
 }
 
+// TableStyle defines appearance of the Table, which is applied when the Table is written.
+// Header rows are the rows marked with TableRow.SetHeader. Background colors set to cells explicitly are kept.
+type TableStyle struct {
+	HeaderBackground      string
+	HeaderFontColor       string
+	HeaderBold            bool
+	BandBackground        string // background of every second body row
+	FirstColumnBackground string
+	FirstColumnBold       bool
+	OuterBorder           TableBorder
+	InnerBorder           TableBorder
+	Padding               int    // cell padding in twips, kept as is if zero
+	VAlign                string // vertical aligning of cells, kept as is if empty
+}
+
+// TableBorder defines border line of the TableStyle. Empty Style means no border.
+type TableBorder struct {
+	Style string
+	Width int
+	Color string
+}
+
+// columnLimit defines width constraints of the auto-fit Table column
+type columnLimit struct {
+	min int
+	max int
+}
+
 // TableCell defines cell properties
 type TableCell struct {
-	cellWidth      int
-	verticalMerged string
-	tableRowWidth  int
-	maxWidth       int
-	vTextAlign     string
-	content        []*Paragraph
+	cellWidth        int
+	verticalMerged   string
+	horizontalMerged string
+	mergeOrigin      *TableCell // first cell of the merged area, placeholders are written with its properties
+	tableRowWidth    int
+	maxWidth         int
+	vTextAlign       string
+	align            string // default aligning of the cell paragraphs
+	textFlow         string
+	noWrap           bool
+	fitText          bool
+	content          []documentItem // paragraphs and nested tables
+	nestLevel        int
+	diagonalDown     borderLine
+	diagonalUp       borderLine
 	borders
 	margins
 	paddings
@@ -106,8 +256,36 @@ type borders struct {
 	borderStyle  string
 	borderWidth  int
 	borderColor  string
+	sides        [6]borderLine // lines overriding common border (left, right, top, bottom, inside horizontal, inside vertical)
 }
 
+// borderLine defines style of the single border line
+type borderLine struct {
+	style string
+	width int
+	color string
+}
+
+// Indexes of the border sides
+const (
+	sideLeft = iota
+	sideRight
+	sideTop
+	sideBottom
+	sideInsideH
+	sideInsideV
+)
+
+// Border sides. Inside borders are defined for tables and rows only.
+const (
+	BorderSideLeft    = "l"
+	BorderSideRight   = "r"
+	BorderSideTop     = "t"
+	BorderSideBottom  = "b"
+	BorderSideInsideH = "h"
+	BorderSideInsideV = "v"
+)
+
 type margins struct {
 	marginLeft   int
 	marginRight  int
@@ -124,13 +302,29 @@ type paddings struct {
 
 // TableRow definces Table Row struct
 type TableRow struct {
-	cells      []*TableCell
-	tableWidth int
-	maxWidth   int
+	cells        []*TableCell
+	tableWidth   int
+	maxWidth     int
+	spans        *[]*cellSpan
+	nestLevel    int
+	isHeader     bool
+	keepTogether bool
+	keepWithNext bool
+	indent       int // left edge of the row, twips
+	height       int // twips, negative height is exact
 	borders
 	generalSettings
 }
 
+// cellSpan defines cell merged with cells of the following rows
+type cellSpan struct {
+	origin   *TableCell
+	column   int   // index of the first spanned column
+	widths   []int // widths of spanned columns
+	rowsLeft int
+	placed   bool // placeholders are added at least into one row
+}
+
 // Main Picture struct
 type Picture struct {
 	format         string // EMF, PNG, JPEG
@@ -151,9 +345,21 @@ type Picture struct {
 
 // Paragraph defines Paragraph instances
 type Paragraph struct {
-	isTable           bool
-	align             string
-	indent            string
+	isTable   bool
+	nestLevel int // table nesting level of the cell paragraph
+	style     string
+	listID    int // list number starting from 1, 0 for paragraph out of list
+	listLevel int
+	isLast    bool // last paragraph of footnote is written without paragraph mark
+	align     string
+	alignSet  bool // align is set explicitly, so it isn't replaced by the cell default
+	paragraphSpacing
+	borders
+	tabStops          []tabStop
+	borderSpace       int // space between borders and text in twips
+	backgroundColor   string
+	shading           int // shading pattern percentage
+	shadingColor      string
 	indentFirstLine   int
 	indentLeftIndent  int
 	indentRightIndent int
@@ -163,6 +369,27 @@ type Paragraph struct {
 	generalSettings
 }
 
+// paragraphSpacing defines spacing and pagination properties of the Paragraph
+type paragraphSpacing struct {
+	spaceBefore     int
+	spaceAfter      int
+	autoSpaceBefore bool
+	autoSpaceAfter  bool
+	lineSpacing     int // \sl value: twips (negative for exact spacing) or 240ths of line for multiple spacing
+	lineMultiple    bool
+	keepWithNext    bool
+	keepTogether    bool
+	widowControl    string // widctlpar, nowidctlpar or empty for the document default
+	pageBreakBefore bool
+}
+
+// tabStop defines Paragraph tab stop
+type tabStop struct {
+	position int
+	align    string
+	leader   string
+}
+
 // Text defines Text instances
 type Text struct {
 	fontSize      int
@@ -178,15 +405,154 @@ type Text struct {
 	emphasis      string
 	content       string
 	rotated       bool
+	style         string
+	// Properties set by Text setters take precedence over style formatting
+	explicitFont     bool
+	explicitFontSize bool
+	explicitColor    bool
 	generalSettings
 }
 
+// specialChar defines Text run of the special character (non-breaking space, em dash, etc.)
+type specialChar struct {
+	Text
+	rtf string
+}
+
+// Field defines dynamic field (page number, date, etc.) of the Paragraph.
+// Text formatting is applied to the field result.
+type Field struct {
+	Text
+	fieldType string
+	switches  string // switches preceding argument
+	argument  string
+	format    string
+}
+
+// FieldOptions defines optional field settings
+type FieldOptions struct {
+	// Format is a date-time picture for date and time fields (e.g. "dd.MM.yyyy")
+	// or a number format (FieldFormatRoman, etc.) for page fields
+	Format string
+	// Placeholder is a field result shown until field is updated by the reader
+	Placeholder string
+}
+
+// bookmark defines named position in the Paragraph
+type bookmark struct {
+	name string
+}
+
+// controlWord is a raw control word in Paragraph content
+type controlWord struct {
+	word string
+}
+
 // Common paper orientation formats
 const (
 	OrientationPortrait  = "orientation_portrait"
 	OrientationLandscape = "orientation_landscape"
 )
 
+// Built-in styles
+const (
+	StyleNormal   = "Normal"
+	StyleHeading1 = "heading 1"
+	StyleHeading2 = "heading 2"
+	StyleHeading3 = "heading 3"
+	StyleHeading4 = "heading 4"
+	StyleHeading5 = "heading 5"
+	StyleHeading6 = "heading 6"
+)
+
+// List kinds
+const (
+	ListNumbered = "list_numbered"
+	ListBulleted = "list_bulleted"
+)
+
+// List number formats
+const (
+	ListFormatDecimal     = "decimal"
+	ListFormatUpperRoman  = "upper_roman"
+	ListFormatLowerRoman  = "lower_roman"
+	ListFormatUpperLetter = "upper_letter"
+	ListFormatLowerLetter = "lower_letter"
+	ListFormatBullet      = "bullet"
+)
+
+// Footnote and endnote numbering restart
+const (
+	NoteRestartContinuous = "rstcont"
+	NoteRestartSection    = "restart"
+	NoteRestartPage       = "rstpg" // footnotes only
+)
+
+// Endnote placement
+const (
+	EndnotesAtDocumentEnd = "aenddoc"
+	EndnotesAtSectionEnd  = "aendnotes"
+)
+
+// Text flow in table cells
+const (
+	TextFlowLeftToRight         = "lrtb"  // horizontal text, default
+	TextFlowTopToBottom         = "tbrl"  // text rotated 90 degrees clockwise
+	TextFlowBottomToTop         = "btlr"  // text rotated 90 degrees counterclockwise
+	TextFlowLeftToRightVertical = "lrtbv" // horizontal text, vertical East Asian characters
+	TextFlowTopToBottomVertical = "tbrlv" // vertical text, vertical East Asian characters
+)
+
+// Reference frames of floating Table position
+const (
+	PositionFromPage      = "pg"
+	PositionFromMargin    = "mrg"
+	PositionFromColumn    = "col"  // horizontal position only
+	PositionFromParagraph = "para" // vertical position only
+)
+
+// Tab stop aligning (AlignLeft, AlignCenter and AlignRight are used too)
+const (
+	TabAlignDecimal = "dec"
+)
+
+// Tab stop leaders
+const (
+	TabLeaderNone       = ""
+	TabLeaderDots       = "dot"
+	TabLeaderMiddleDots = "mdot"
+	TabLeaderHyphens    = "hyph"
+	TabLeaderUnderline  = "ul"
+	TabLeaderThick      = "th"
+	TabLeaderEqual      = "eq"
+)
+
+// Line spacing rules
+const (
+	LineSpacingMultiple = "multiple" // spacing in 240ths of line
+	LineSpacingExact    = "exact"    // spacing in twips
+	LineSpacingAtLeast  = "atLeast"  // spacing in twips
+)
+
+// Multiple line spacing values
+const (
+	LineSpacingSingle     = 240
+	LineSpacingOneAndHalf = 360
+	LineSpacingDouble     = 480
+)
+
+// Table row height rules
+const (
+	RowHeightAtLeast = "atLeast"
+	RowHeightExact   = "exact"
+)
+
+// Output profiles
+const (
+	OutputProfileFull      = "output_profile_full"       // all character formatting is written
+	OutputProfilePlainText = "output_profile_plain_text" // only text and font size are written
+)
+
 // Commont paper formats
 const (
 	FormatLetter = "format_Letter"
@@ -240,6 +606,33 @@ const (
 	BorderEngrave             = "engrave"
 )
 
+// Common field types
+const (
+	FieldPage         = "PAGE"
+	FieldNumPages     = "NUMPAGES"
+	FieldSectionPages = "SECTIONPAGES"
+	FieldDate         = "DATE"
+	FieldTime         = "TIME"
+	FieldHyperlink    = "HYPERLINK"
+)
+
+// Number formats of page fields
+const (
+	FieldFormatArabic          = "arabic"
+	FieldFormatRoman           = "roman"
+	FieldFormatUpperRoman      = "ROMAN"
+	FieldFormatAlphabetic      = "alphabetic"
+	FieldFormatUpperAlphabetic = "ALPHABETIC"
+)
+
+// Pages of header and footer
+const (
+	HeaderFooterAll   = ""  // all pages (or pages without own header or footer)
+	HeaderFooterFirst = "f" // first page only
+	HeaderFooterLeft  = "l" // left (even) pages only
+	HeaderFooterRight = "r" // right (odd) pages only
+)
+
 // Common image formats
 const (
 	ImageFormatJpeg = "jpeg"
@@ -3,8 +3,6 @@ package rtfdoc
 import (
 	"fmt"
 	"strings"
-	"unicode"
-	"unicode/utf16"
 )
 
 const kEndOfASCII = 0x7F
@@ -17,6 +15,40 @@ const kHigh10BitsShiftToLow10Bits = 0x400
 const kLow10Bits = 0x3FF
 const kUnsigned16BitValueIntoSigned16BitValueRange = 0x10000
 
+// escapeText prepares user-supplied string to be written into document: reserved characters
+// are escaped, line breaks and tabs are replaced with control words and non-ASCII characters
+// are converted to \u control words.
+func escapeText(text string) string {
+	var res strings.Builder
+	for _, r := range text {
+		switch r {
+		case '\\', '{', '}':
+			res.WriteRune('\\')
+			res.WriteRune(r)
+		case '\n':
+			res.WriteString("\\line ")
+		case '\t':
+			res.WriteString("\\tab ")
+		case '\r':
+		default:
+			res.WriteRune(r)
+		}
+	}
+	return convertNonASCIIToUTF16(res.String())
+}
+
+// escapeName prepares font or style name to be written into the table entry, which ends with ';'.
+// Semicolons, line breaks and tabs are removed from the name.
+func escapeName(name string) string {
+	return escapeText(strings.Map(func(r rune) rune {
+		switch r {
+		case ';', '\n', '\t', '\r':
+			return -1
+		}
+		return r
+	}, name))
+}
+
 func convertNonASCIIToUTF16(text string) string {
 	var res strings.Builder
 	for _, r := range text {
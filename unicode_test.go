@@ -0,0 +1,60 @@
+package rtfdoc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"plain", "plain"},
+		{`a\b`, `a\\b`},
+		{"{x}", `\{x\}`},
+		{"a;b", "a;b"},
+		{"line\r\nnext\tcol", `line\line next\tab col`},
+		{"café", `caf\u233\'5f`},
+		{"Жук", `\u1046\'5f\u1091\'5f\u1082\'5f`},
+		{"😀", `\u-10179\'5f\u-8704\'5f`},
+	}
+	for _, tt := range tests {
+		if got := escapeText(tt.text); got != tt.want {
+			t.Errorf("escapeText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Arial", "Arial"},
+		{"Font; Bold", "Font Bold"},
+		{`Back\slash {x}`, `Back\\slash \{x\}`},
+		{"Line\nbreak\t;", "Linebreak"},
+		{"Шрифт", `\u1064\'5f\u1088\'5f\u1080\'5f\u1092\'5f\u1090\'5f`},
+	}
+	for _, tt := range tests {
+		if got := escapeName(tt.name); got != tt.want {
+			t.Errorf("escapeName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTableEntryNames(t *testing.T) {
+	doc := NewDocument()
+	doc.fontColor.AddFont("swiss", 0, 2, "My;Font", "myfont")
+	doc.AddParagraphStyle("Quote; {x}", StyleNormal)
+	header := string(doc.Export())
+	for _, want := range []string{
+		"{\\f5\\fswiss\\fprq2\\fcharset0 MyFont;}",
+		"\\snext7 Quote \\{x\\};}",
+	} {
+		if !strings.Contains(header, want) {
+			t.Errorf("%q not found in\n%s", want, header)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package rtfdoc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// rtfWriter wraps output stream for composing functions. It keeps the first write error,
+// so composers don't have to check errors after every write.
+type rtfWriter struct {
+	w        io.Writer
+	err      error
+	profile  string // output profile of the document being written
+	parStyle *Style // resolved style of the paragraph being written
+}
+
+// countingWriter counts bytes accepted by the destination writer
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func newRTFWriter(w io.Writer, profile string) *rtfWriter {
+	return &rtfWriter{w: w, profile: profile}
+}
+
+// Write implements io.Writer, so rtfWriter may be used as destination for encoders (hex, etc.)
+func (rw *rtfWriter) Write(p []byte) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	n, err := rw.w.Write(p)
+	rw.err = err
+	return n, err
+}
+
+// WriteString writes string s to the underlying writer
+func (rw *rtfWriter) WriteString(s string) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	n, err := io.WriteString(rw.w, s)
+	rw.err = err
+	return n, err
+}
+
+func (rw *rtfWriter) printf(format string, args ...interface{}) {
+	if rw.err != nil {
+		return
+	}
+	_, rw.err = fmt.Fprintf(rw.w, format, args...)
+}
+
+// writeComposed streams composer output to w through buffer and returns number of bytes written to w
+func writeComposed(w io.Writer, compose func(rw *rtfWriter)) (int64, error) {
+	return writeComposedWithProfile(w, OutputProfileFull, compose)
+}
+
+func writeComposedWithProfile(w io.Writer, profile string, compose func(rw *rtfWriter)) (int64, error) {
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	rw := newRTFWriter(bw, profile)
+	compose(rw)
+	if rw.err == nil {
+		rw.err = bw.Flush()
+	}
+	return cw.n, rw.err
+}